@@ -0,0 +1,143 @@
+package goleaderboard
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// AggregateOptions contains all configs for aggregate leaderboard
+type AggregateOptions struct {
+	// Boards is names of leaderboards which are aggregated, they must be ranked with or without same rank
+	// like Options, and must not use tie break. With Redis Cluster and Options.ClusterKeys, names of boards and of the
+	// aggregate leaderboard must have the same hash tag, for example "{kills}:solo", "{kills}:duo" and "{kills}:all".
+	Boards []string
+	// Weights multiply scores of members in every board before they are aggregated, default is 1 for all boards.
+	Weights []float64
+	// Intersect keeps only members which are in all boards, default is members which are in any board.
+	Intersect bool
+	// Aggregate is the way to combine scores of a member in boards, default is AggregateSum.
+	Aggregate Aggregate
+	// RefreshInterval is how long the aggregated board is kept before it is aggregated again on read,
+	// 0 means it is only aggregated by Refresh.
+	RefreshInterval time.Duration
+	// Options is configs of the aggregated board, TieBreak and LifeTime are not used. With IncludeMetadata,
+	// metadata of a member is copied from the first board of Boards which has it.
+	Options Options
+}
+
+// TypedAggregateLeaderboard defines a leaderboard stored in Redis which ranks members by their scores in several
+// leaderboards, for example a ranking of all game modes from a leaderboard of every mode.
+// Boards are aggregated to a derived board by ZUNIONSTORE or ZINTERSTORE, on demand or on read every RefreshInterval.
+type TypedAggregateLeaderboard[ID comparable, S Score] struct {
+	mergedReader[ID, S]
+	redisClient redis.UniversalClient
+	name        string
+	codec       IDCodec[ID]
+	mergeScript *redis.Script
+	opts        *AggregateOptions
+}
+
+// AggregateLeaderboard defines an aggregate leaderboard with member ids of any type.
+type AggregateLeaderboard = TypedAggregateLeaderboard[interface{}, int]
+
+// NewAggregateLeaderBoard create a new aggregate leaderboard stored in Redis with specific name and configs.
+func NewAggregateLeaderBoard(redisClient redis.UniversalClient, name string, opts *AggregateOptions) (*AggregateLeaderboard, error) {
+	return NewTypedAggregateLeaderBoard[interface{}, int](redisClient, name, AnyCodec{}, opts)
+}
+
+// NewTypedAggregateLeaderBoard create a new aggregate leaderboard stored in Redis whose member ids have type ID
+// and are encoded by codec, scores have type S.
+// It returns an error if there is no board to aggregate, the number of weights is not the number of boards
+// or a config of Options has an unknown value.
+func NewTypedAggregateLeaderBoard[ID comparable, S Score](redisClient redis.UniversalClient, name string, codec IDCodec[ID], opts *AggregateOptions) (*TypedAggregateLeaderboard[ID, S], error) {
+	if opts == nil || len(opts.Boards) == 0 {
+		return nil, fmt.Errorf("goleaderboard: aggregate leaderboard needs at least 1 board")
+	}
+
+	if opts.Weights != nil && len(opts.Weights) != len(opts.Boards) {
+		return nil, fmt.Errorf("goleaderboard: aggregate leaderboard needs %v weights, got %v", len(opts.Boards), len(opts.Weights))
+	}
+
+	if err := opts.Options.validate(); err != nil {
+		return nil, err
+	}
+
+	lb := &TypedAggregateLeaderboard[ID, S]{
+		redisClient: redisClient,
+		name:        name,
+		codec:       codec,
+		mergeScript: redis.NewScript(initMergeScript()),
+		opts:        opts,
+	}
+	lb.mergedReader = mergedReader[ID, S]{merged: lb.merged}
+
+	return lb, nil
+}
+
+func (a *TypedAggregateLeaderboard[ID, S]) weights() []float64 {
+	if a.opts.Weights != nil {
+		return a.opts.Weights
+	}
+
+	weights := make([]float64, len(a.opts.Boards))
+	for idx := range weights {
+		weights[idx] = 1
+	}
+
+	return weights
+}
+
+func (a *TypedAggregateLeaderboard[ID, S]) aggregate() Aggregate {
+	if a.opts.Aggregate != "" {
+		return a.opts.Aggregate
+	}
+
+	return AggregateSum
+}
+
+func (a *TypedAggregateLeaderboard[ID, S]) boardOptions() *Options {
+	opts := a.opts.Options
+	opts.TieBreak = ""
+	opts.LifeTime = 0
+	return &opts
+}
+
+func (a *TypedAggregateLeaderboard[ID, S]) merge(ctx context.Context, force bool) error {
+	return mergeBoards(
+		ctx,
+		a.redisClient,
+		a.mergeScript,
+		a.name,
+		a.opts.Intersect,
+		a.aggregate(),
+		a.opts.RefreshInterval,
+		force,
+		a.boardOptions(),
+		a.opts.Boards,
+		a.weights(),
+	)
+}
+
+// Refresh aggregate boards again now.
+func (a *TypedAggregateLeaderboard[ID, S]) Refresh(ctx context.Context) error {
+	return a.merge(ctx, true)
+}
+
+// merged get the aggregated board, boards are aggregated again if it is older than RefreshInterval.
+func (a *TypedAggregateLeaderboard[ID, S]) merged(ctx context.Context) (TypedLeaderboard[ID, S], error) {
+	if a.opts.RefreshInterval > 0 {
+		if err := a.merge(ctx, false); err != nil {
+			return nil, err
+		}
+	}
+
+	return NewTypedLeaderBoard[ID, S](a.redisClient, a.name, a.codec, a.boardOptions()), nil
+}
+
+// Clean clear the aggregated board in redis, Boards are not changed
+func (a *TypedAggregateLeaderboard[ID, S]) Clean(ctx context.Context) error {
+	return NewTypedLeaderBoard[ID, S](a.redisClient, a.name, a.codec, a.boardOptions()).Clean(ctx)
+}
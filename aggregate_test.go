@@ -0,0 +1,141 @@
+package goleaderboard
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestAggregateLeaderboard(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	testCases := []struct {
+		allowSameRank bool
+	}{
+		{
+			allowSameRank: false,
+		},
+		{
+			allowSameRank: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		ctx := context.Background()
+		opts := Options{AllowSameRank: tc.allowSameRank}
+
+		solo := NewLeaderBoard(redisClient, "test:solo", &opts)
+		defer clean(t, ctx, solo)
+		addMember(t, ctx, solo, "P1", 10)
+		addMember(t, ctx, solo, "P2", 30)
+		addMember(t, ctx, solo, "P3", 5)
+
+		duo := NewLeaderBoard(redisClient, "test:duo", &opts)
+		defer clean(t, ctx, duo)
+		addMember(t, ctx, duo, "P1", 10)
+		addMember(t, ctx, duo, "P3", 20)
+
+		all, err := NewAggregateLeaderBoard(redisClient, "test:all", &AggregateOptions{
+			Boards:  []string{"test:solo", "test:duo"},
+			Weights: []float64{1, 2},
+			Options: opts,
+		})
+		if err != nil {
+			t.Fatal("failed to create aggregate leaderboard", err.Error())
+		}
+		defer func() {
+			if err := all.Clean(ctx); err != nil {
+				t.Fatal("failed to clean aggregate leaderboard", err.Error())
+			}
+		}()
+
+		if err := all.Refresh(ctx); err != nil {
+			t.Error("failed to refresh aggregate leaderboard", err.Error())
+			return
+		}
+
+		members, cursor, err := all.List(ctx, 0, 10, OrderDesc)
+		if err != nil {
+			t.Error("failed to list members", err.Error())
+			return
+		}
+
+		// P3 has 5 + 20 * 2, P1 has 10 + 10 * 2 like P2
+		if cursor.Total != 3 || members[0].ID != "P3" || members[0].Score != 45 || members[1].Score != 30 || members[2].Score != 30 {
+			t.Errorf("Error in list union of boards, same rank %v\nExpected: P3 with 45 then 30, 30\nReceived: %+v", tc.allowSameRank, members)
+		}
+
+		if tc.allowSameRank && members[1].Rank != members[2].Rank {
+			t.Errorf("Error in rank union of boards with same rank\nExpected: same rank\nReceived: %+v", members)
+		}
+
+		// the aggregated board is not changed until it is refreshed
+		addMember(t, ctx, duo, "P2", 100)
+		if rank, err := all.GetRank(ctx, "P3"); err != nil || rank != 1 {
+			t.Errorf("Error in get rank before refresh, same rank %v\nExpected: rank #1\nReceived: rank #%v, %v", tc.allowSameRank, rank, err)
+		}
+		if err := all.Refresh(ctx); err != nil {
+			t.Error("failed to refresh aggregate leaderboard", err.Error())
+			return
+		}
+		if rank, err := all.GetRank(ctx, "P2"); err != nil || rank != 1 {
+			t.Errorf("Error in get rank after refresh, same rank %v\nExpected: rank #1\nReceived: rank #%v, %v", tc.allowSameRank, rank, err)
+		}
+
+		both, err := NewAggregateLeaderBoard(redisClient, "test:both", &AggregateOptions{
+			Boards:          []string{"test:solo", "test:duo"},
+			Intersect:       true,
+			Aggregate:       AggregateMax,
+			RefreshInterval: time.Minute,
+			Options:         opts,
+		})
+		if err != nil {
+			t.Fatal("failed to create aggregate leaderboard", err.Error())
+		}
+		defer func() {
+			if err := both.Clean(ctx); err != nil {
+				t.Fatal("failed to clean aggregate leaderboard", err.Error())
+			}
+		}()
+
+		member, err := both.GetMember(ctx, "P2")
+		if err != nil || member.Score != 100 {
+			t.Errorf("Error in get member of intersection, same rank %v\nExpected: score 100\nReceived: %+v, %v", tc.allowSameRank, member, err)
+		}
+
+		count, err := both.Count(ctx)
+		if err != nil || count != 3 {
+			t.Errorf("Error in count intersection, same rank %v\nExpected: 3\nReceived: %v, %v", tc.allowSameRank, count, err)
+		}
+
+		if err := solo.RemoveMember(ctx, "P1"); err != nil {
+			t.Error("failed to remove member", err.Error())
+			return
+		}
+
+		// the intersection is aggregated again on read after RefreshInterval
+		if _, err := both.GetRank(ctx, "P1"); err != nil {
+			t.Errorf("Error in get rank of cached intersection, same rank %v\nExpected: found\nReceived: %v", tc.allowSameRank, err)
+		}
+		if err := both.Refresh(ctx); err != nil {
+			t.Error("failed to refresh aggregate leaderboard", err.Error())
+			return
+		}
+		if _, err := both.GetRank(ctx, "P1"); !errors.Is(err, ErrMemberNotFound) {
+			t.Errorf("Error in get rank of refreshed intersection, same rank %v\nExpected: %v\nReceived: %v", tc.allowSameRank, ErrMemberNotFound, err)
+		}
+	}
+}
+
+func TestNewAggregateLeaderboard(t *testing.T) {
+	if _, err := NewAggregateLeaderBoard(nil, "test", &AggregateOptions{}); err == nil {
+		t.Error("Error in create aggregate leaderboard without boards\nExpected: error\nReceived: nil")
+	}
+
+	_, err := NewAggregateLeaderBoard(nil, "test", &AggregateOptions{Boards: []string{"a", "b"}, Weights: []float64{1}})
+	if err == nil {
+		t.Error("Error in create aggregate leaderboard with missing weights\nExpected: error\nReceived: nil")
+	}
+}
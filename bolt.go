@@ -0,0 +1,1008 @@
+package goleaderboard
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	boltMembersBucket     = []byte("members")
+	boltKeysBucket        = []byte("keys")
+	boltMetadataBucket    = []byte("metadata")
+	boltCountsBucket      = []byte("counts")
+	boltScoreCountsBucket = []byte("score_counts")
+	boltExpireAtKey       = []byte("expire_at")
+	boltTotalKey          = []byte("total")
+)
+
+const (
+	// boltScoreSize is the size of the score at the beginning of a key.
+	boltScoreSize = 8
+	// boltKeySize is the size of the score and the time of a key, the member id follows them.
+	boltKeySize = 16
+)
+
+// encodeBoltScore encode a score to bytes which are ordered like scores.
+func encodeBoltScore(score float64) []byte {
+	if score == 0 {
+		// -0 and 0 are the same score
+		score = 0
+	}
+
+	bits := math.Float64bits(score)
+	if bits>>63 == 1 {
+		bits = ^bits
+	} else {
+		bits |= 1 << 63
+	}
+
+	return binary.BigEndian.AppendUint64(nil, bits)
+}
+
+func decodeBoltScore(buf []byte) float64 {
+	bits := binary.BigEndian.Uint64(buf)
+	if bits>>63 == 1 {
+		bits &^= 1 << 63
+	} else {
+		bits = ^bits
+	}
+
+	return math.Float64frombits(bits)
+}
+
+// boltKey get key of a member which is ordered by score, then by the time it reached the score with tie break,
+// then by id, like members of Redis leaderboard.
+func boltKey(score float64, tieBreak int64, id string) []byte {
+	key := make([]byte, 0, boltKeySize+len(id))
+	key = append(key, encodeBoltScore(score)...)
+	key = binary.BigEndian.AppendUint64(key, uint64(tieBreak)^1<<63)
+	return append(key, id...)
+}
+
+func encodeBoltCount(count int) []byte {
+	return binary.BigEndian.AppendUint64(nil, uint64(count))
+}
+
+func decodeBoltCount(buf []byte) int {
+	if len(buf) != 8 {
+		return 0
+	}
+
+	return int(binary.BigEndian.Uint64(buf))
+}
+
+// boltCounter counts keys by all of their prefixes, so keys are counted and found by their index without scanning them.
+// Counts of prefixes of length n+1 are in the nested bucket of level n, so children of a prefix are next to each other
+// and there are at most 256 of them.
+type boltCounter struct {
+	bucket *bolt.Bucket
+}
+
+func boltLevelName(level int) []byte {
+	return binary.BigEndian.AppendUint16(nil, uint16(level))
+}
+
+func (c boltCounter) level(level int) *bolt.Bucket {
+	if c.bucket == nil {
+		return nil
+	}
+
+	return c.bucket.Bucket(boltLevelName(level))
+}
+
+// Total get number of keys.
+func (c boltCounter) Total() int {
+	if c.bucket == nil {
+		return 0
+	}
+
+	return decodeBoltCount(c.bucket.Get(boltTotalKey))
+}
+
+// Count get number of keys which start with prefix.
+func (c boltCounter) Count(prefix []byte) int {
+	level := c.level(len(prefix) - 1)
+	if level == nil {
+		return 0
+	}
+
+	return decodeBoltCount(level.Get(prefix))
+}
+
+// Add add delta to count of key, key must not be changed until the transaction ends.
+func (c boltCounter) Add(key []byte, delta int) error {
+	for idx := range key {
+		level, err := c.bucket.CreateBucketIfNotExists(boltLevelName(idx))
+		if err != nil {
+			return err
+		}
+
+		prefix := key[:idx+1]
+		count := decodeBoltCount(level.Get(prefix)) + delta
+		if count > 0 {
+			err = level.Put(prefix, encodeBoltCount(count))
+		} else {
+			err = level.Delete(prefix)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return c.bucket.Put(boltTotalKey, encodeBoltCount(c.Total()+delta))
+}
+
+// Greater get number of keys which are greater than key and do not start with it.
+func (c boltCounter) Greater(key []byte) int {
+	count := 0
+	for idx := range key {
+		if key[idx] == math.MaxUint8 {
+			continue
+		}
+
+		level := c.level(idx)
+		if level == nil {
+			break
+		}
+
+		parent := key[:idx]
+		seek := append(append(make([]byte, 0, idx+1), parent...), key[idx]+1)
+		cursor := level.Cursor()
+		for k, v := cursor.Seek(seek); k != nil && bytes.HasPrefix(k, parent); k, v = cursor.Next() {
+			count += decodeBoltCount(v)
+		}
+	}
+
+	return count
+}
+
+// At get the key at index from 0 in ascending order, it is nil if index is out of range.
+// isKey reports whether a prefix is a key itself, it is before the other keys which start with it.
+func (c boltCounter) At(index int, isKey func(prefix []byte) bool) []byte {
+	if index < 0 || index >= c.Total() {
+		return nil
+	}
+
+	prefix := []byte{}
+	for depth := 0; ; depth++ {
+		if depth > 0 && isKey(prefix) {
+			if index == 0 {
+				return prefix
+			}
+			index--
+		}
+
+		level := c.level(depth)
+		if level == nil {
+			return nil
+		}
+
+		var next []byte
+		cursor := level.Cursor()
+		for k, v := cursor.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = cursor.Next() {
+			count := decodeBoltCount(v)
+			if index < count {
+				next = append([]byte{}, k...)
+				break
+			}
+			index -= count
+		}
+
+		if next == nil {
+			return nil
+		}
+		prefix = next
+	}
+}
+
+// boltBoard is the data of a bolt leaderboard in a transaction, all buckets are nil if leaderboard is empty.
+type boltBoard struct {
+	members  *bolt.Bucket
+	keys     *bolt.Bucket
+	metadata *bolt.Bucket
+	counts   boltCounter
+	// scoreCounts counts distinct scores for RankDense
+	scoreCounts boltCounter
+}
+
+func newBoltBoard(root *bolt.Bucket) *boltBoard {
+	if root == nil {
+		return &boltBoard{}
+	}
+
+	return &boltBoard{
+		members:     root.Bucket(boltMembersBucket),
+		keys:        root.Bucket(boltKeysBucket),
+		metadata:    root.Bucket(boltMetadataBucket),
+		counts:      boltCounter{bucket: root.Bucket(boltCountsBucket)},
+		scoreCounts: boltCounter{bucket: root.Bucket(boltScoreCountsBucket)},
+	}
+}
+
+func createBoltBoard(root *bolt.Bucket) (*boltBoard, error) {
+	for _, name := range [][]byte{boltMembersBucket, boltKeysBucket, boltMetadataBucket, boltCountsBucket, boltScoreCountsBucket} {
+		if _, err := root.CreateBucketIfNotExists(name); err != nil {
+			return nil, err
+		}
+	}
+
+	return newBoltBoard(root), nil
+}
+
+// key get key of a member, it is nil if member is not in leaderboard.
+func (b *boltBoard) key(memberID string) []byte {
+	if b.members == nil {
+		return nil
+	}
+
+	return b.members.Get([]byte(memberID))
+}
+
+func (b *boltBoard) isKey(prefix []byte) bool {
+	return len(prefix) >= boltKeySize && b.keys.Get(prefix) != nil
+}
+
+func (b *boltBoard) add(key []byte) error {
+	memberID := key[boltKeySize:]
+	if err := b.remove(string(memberID)); err != nil {
+		return err
+	}
+
+	if err := b.members.Put(memberID, key); err != nil {
+		return err
+	}
+
+	if err := b.keys.Put(key, []byte{1}); err != nil {
+		return err
+	}
+
+	if b.counts.Count(key[:boltScoreSize]) == 0 {
+		if err := b.scoreCounts.Add(key[:boltScoreSize], 1); err != nil {
+			return err
+		}
+	}
+
+	return b.counts.Add(key, 1)
+}
+
+func (b *boltBoard) remove(memberID string) error {
+	key := b.key(memberID)
+	if key == nil {
+		return nil
+	}
+
+	// the key is only valid until it is deleted
+	key = append([]byte{}, key...)
+	if err := b.members.Delete([]byte(memberID)); err != nil {
+		return err
+	}
+
+	if err := b.keys.Delete(key); err != nil {
+		return err
+	}
+
+	if err := b.counts.Add(key, -1); err != nil {
+		return err
+	}
+
+	if b.counts.Count(key[:boltScoreSize]) == 0 {
+		return b.scoreCounts.Add(key[:boltScoreSize], -1)
+	}
+
+	return nil
+}
+
+// countScores get number of members whose score is less than score, or equal to it if inclusive.
+func (b *boltBoard) countScores(score float64, inclusive bool) int {
+	prefix := encodeBoltScore(score)
+	count := b.counts.Total() - b.counts.Greater(prefix)
+	if !inclusive {
+		count -= b.counts.Count(prefix)
+	}
+
+	return count
+}
+
+// index get index of a key from 0 in ascending order.
+func (b *boltBoard) index(key []byte) int {
+	return b.counts.Total() - b.rank(key, RankOrdinal)
+}
+
+// rank get rank of member like scripts of Redis leaderboard.
+func (b *boltBoard) rank(key []byte, scheme RankingScheme) int {
+	score := key[:boltScoreSize]
+	switch scheme {
+	case RankDense:
+		return b.scoreCounts.Greater(score) + 1
+	case RankStandardCompetition:
+		return b.counts.Greater(score) + 1
+	case RankModifiedCompetition:
+		return b.counts.Greater(score) + b.counts.Count(score)
+	default:
+		return b.counts.Greater(key) + b.counts.Count(key)
+	}
+}
+
+// TypedBoltLeaderboard defines a leaderboard stored in a bolt database whose member ids have type ID and scores have type S,
+// follows TypedLeaderboard interface. It is for servers without Redis, data is kept in a file and survives restarts.
+// Members are stored by keys ordered by score and every prefix of keys is counted, so members are ranked and listed
+// from an offset without scanning members before them.
+type TypedBoltLeaderboard[ID comparable, S Score] struct {
+	db     *bolt.DB
+	bucket []byte
+	codec  IDCodec[ID]
+	opts   *Options
+}
+
+// BoltLeaderboard defines a leaderboard stored in a bolt database with member ids of any type, follows Leaderboard interface
+type BoltLeaderboard = TypedBoltLeaderboard[interface{}, int]
+
+// NewBoltLeaderBoard create a new leaderboard stored in a bolt database with specific name and configs.
+// The database is opened and closed by caller, many leaderboards can be stored in the same database.
+// You can see all supported config in type `Options`, if a config has an unknown value every operation returns an error.
+func NewBoltLeaderBoard(db *bolt.DB, name string, opts *Options) Leaderboard {
+	return NewTypedBoltLeaderBoard[interface{}, int](db, name, AnyCodec{}, opts)
+}
+
+// NewTypedBoltLeaderBoard create a new leaderboard stored in a bolt database whose member ids have type ID and are encoded
+// by codec, scores have type S.
+// You can see all supported config in type `Options`, if a config has an unknown value every operation returns an error.
+func NewTypedBoltLeaderBoard[ID comparable, S Score](db *bolt.DB, name string, codec IDCodec[ID], opts *Options) TypedLeaderboard[ID, S] {
+	if opts == nil {
+		opts = &Options{
+			RankingScheme: RankOrdinal,
+			LifeTime:      1 * time.Hour,
+		}
+	}
+	if err := opts.validate(); err != nil {
+		return &invalidLeaderboard[ID, S]{err: err}
+	}
+
+	return &TypedBoltLeaderboard[ID, S]{
+		db:     db,
+		bucket: []byte(fmt.Sprintf("goleaderboard:%s", name)),
+		codec:  codec,
+		opts:   opts,
+	}
+}
+
+// options get configs of leaderboard, so leaderboards built on it like CompositeLeaderboard can check them.
+func (l *TypedBoltLeaderboard[ID, S]) options() *Options {
+	return l.opts
+}
+
+func (l *TypedBoltLeaderboard[ID, S]) expired(root *bolt.Bucket) bool {
+	expireAt := root.Get(boltExpireAtKey)
+	return expireAt != nil && !now().Before(time.Unix(0, int64(binary.BigEndian.Uint64(expireAt))))
+}
+
+// view get data of leaderboard to read, it is empty if leaderboard expired.
+func (l *TypedBoltLeaderboard[ID, S]) view(tx *bolt.Tx) *boltBoard {
+	root := tx.Bucket(l.bucket)
+	if root != nil && l.expired(root) {
+		return &boltBoard{}
+	}
+
+	return newBoltBoard(root)
+}
+
+// write get data of leaderboard to write, it is cleared if leaderboard expired.
+// Expiry is extended by Options.LifeTime.
+func (l *TypedBoltLeaderboard[ID, S]) write(tx *bolt.Tx) (*boltBoard, error) {
+	root := tx.Bucket(l.bucket)
+	if root != nil && l.expired(root) {
+		if err := tx.DeleteBucket(l.bucket); err != nil {
+			return nil, err
+		}
+	}
+
+	root, err := tx.CreateBucketIfNotExists(l.bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	if l.opts.LifeTime > 0 {
+		expireAt := binary.BigEndian.AppendUint64(nil, uint64(now().Add(l.opts.lifeTime()).UnixNano()))
+		if err := root.Put(boltExpireAtKey, expireAt); err != nil {
+			return nil, err
+		}
+	}
+
+	return createBoltBoard(root)
+}
+
+// member get a member from its key.
+func (l *TypedBoltLeaderboard[ID, S]) member(board *boltBoard, id ID, key []byte) (*TypedMember[ID, S], error) {
+	member := &TypedMember[ID, S]{
+		ID:    id,
+		Score: S(decodeBoltScore(key)),
+		Rank:  board.rank(key, l.opts.rankingScheme()),
+	}
+
+	if l.opts.IncludeMetadata && board.metadata != nil {
+		if value := board.metadata.Get(key[boltKeySize:]); value != nil {
+			if err := json.Unmarshal(value, &member.Metadata); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return member, nil
+}
+
+// decodeMember get a member from its key, its id is decoded like ids listed from Redis.
+func (l *TypedBoltLeaderboard[ID, S]) decodeMember(board *boltBoard, key []byte) (*TypedMember[ID, S], error) {
+	id, err := l.codec.DecodeID(string(key[boltKeySize:]))
+	if err != nil {
+		return nil, err
+	}
+
+	return l.member(board, id, key)
+}
+
+// checkExactScore check a score can be stored in a float64 without losing precision like in Redis.
+func checkExactScore[S Score](score S) error {
+	if err := checkScore(score); err != nil {
+		return err
+	}
+
+	if !isFloatScore[S]() && (int64(score) > maxExactScore || int64(score) < -maxExactScore) {
+		return ErrScoreOutOfRange
+	}
+
+	return nil
+}
+
+// update apply policy to score of a member, it reports whether the score was changed.
+func (l *TypedBoltLeaderboard[ID, S]) update(board *boltBoard, memberID string, score S, policy UpdatePolicy) (bool, error) {
+	if old := board.key(memberID); old != nil {
+		oldScore := S(decodeBoltScore(old))
+		switch policy {
+		case UpdateSum:
+			score = oldScore + score
+		case UpdateKeepHighest:
+			if score < oldScore {
+				score = oldScore
+			}
+		case UpdateKeepLowest:
+			if score > oldScore {
+				score = oldScore
+			}
+		}
+
+		if score == oldScore {
+			return false, nil
+		}
+	}
+
+	if err := checkExactScore(score); err != nil {
+		return false, err
+	}
+
+	return true, board.add(boltKey(float64(score), keyTieBreakTime(l.opts), memberID))
+}
+
+// AddMember add a member with score to leaderboard.
+// Score of a member which was already in leaderboard is updated by Options.UpdatePolicy.
+func (l *TypedBoltLeaderboard[ID, S]) AddMember(ctx context.Context, id ID, score S) error {
+	_, err := l.UpdateMember(ctx, id, score, l.opts.updatePolicy())
+	return err
+}
+
+// AddMemberWithMetadata add a member with score to leaderboard like AddMember, and store metadata of member.
+// Metadata replaces the one stored before, nil metadata keeps it.
+func (l *TypedBoltLeaderboard[ID, S]) AddMemberWithMetadata(ctx context.Context, id ID, score S, metadata map[string]string) error {
+	return l.AddMembers(ctx, []TypedMember[ID, S]{{ID: id, Score: score, Metadata: metadata}})
+}
+
+// AddMembers add a list of members with their score to leaderboard in one transaction.
+// It works the same as AddMember for every member, field Rank of members is ignored.
+// Metadata of members is stored like AddMemberWithMetadata if it is not nil.
+func (l *TypedBoltLeaderboard[ID, S]) AddMembers(ctx context.Context, members []TypedMember[ID, S]) error {
+	if len(members) == 0 {
+		return nil
+	}
+
+	memberIDs := make([]string, 0, len(members))
+	for _, member := range members {
+		memberID, err := l.codec.EncodeID(member.ID)
+		if err != nil {
+			return err
+		}
+
+		if err := checkExactScore(member.Score); err != nil {
+			return err
+		}
+		memberIDs = append(memberIDs, memberID)
+	}
+
+	return l.db.Update(func(tx *bolt.Tx) error {
+		board, err := l.write(tx)
+		if err != nil {
+			return err
+		}
+
+		for idx, member := range members {
+			if _, err := l.update(board, memberIDs[idx], member.Score, l.opts.updatePolicy()); err != nil {
+				return err
+			}
+
+			if member.Metadata == nil {
+				continue
+			}
+
+			value, err := json.Marshal(member.Metadata)
+			if err != nil {
+				return err
+			}
+
+			if err := board.metadata.Put([]byte(memberIDs[idx]), value); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// UpdateMember add a member with score to leaderboard like AddMember, but update its score by policy instead of
+// Options.UpdatePolicy. It reports whether the stored score was changed, or returns an error if policy is unknown.
+func (l *TypedBoltLeaderboard[ID, S]) UpdateMember(ctx context.Context, id ID, score S, policy UpdatePolicy) (bool, error) {
+	if err := checkUpdatePolicy(policy); err != nil {
+		return false, err
+	}
+
+	memberID, err := l.codec.EncodeID(id)
+	if err != nil {
+		return false, err
+	}
+
+	if err := checkExactScore(score); err != nil {
+		return false, err
+	}
+
+	updated := false
+	err = l.db.Update(func(tx *bolt.Tx) error {
+		board, err := l.write(tx)
+		if err != nil {
+			return err
+		}
+
+		updated, err = l.update(board, memberID, score, policy)
+		return err
+	})
+
+	return updated, err
+}
+
+// IncrementScore increase score of a member by delta atomically, a negative delta will decrease it.
+// If member was not in leaderboard, it will be added with score is delta.
+// It returns the member with new score and rank.
+func (l *TypedBoltLeaderboard[ID, S]) IncrementScore(ctx context.Context, id ID, delta S) (*TypedMember[ID, S], error) {
+	memberID, err := l.codec.EncodeID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkExactScore(delta); err != nil {
+		return nil, err
+	}
+
+	var member *TypedMember[ID, S]
+	err = l.db.Update(func(tx *bolt.Tx) error {
+		board, err := l.write(tx)
+		if err != nil {
+			return err
+		}
+
+		if _, err := l.update(board, memberID, delta, UpdateSum); err != nil {
+			return err
+		}
+
+		key := board.key(memberID)
+		member = &TypedMember[ID, S]{
+			ID:    id,
+			Score: S(decodeBoltScore(key)),
+			Rank:  board.rank(key, l.opts.rankingScheme()),
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
+
+// RemoveMember remove members and their metadata from leaderboard by their ids, ids which are not in leaderboard will be ignored.
+func (l *TypedBoltLeaderboard[ID, S]) RemoveMember(ctx context.Context, ids ...ID) error {
+	memberIDs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		memberID, err := l.codec.EncodeID(id)
+		if err != nil {
+			return err
+		}
+		memberIDs = append(memberIDs, memberID)
+	}
+
+	return l.db.Update(func(tx *bolt.Tx) error {
+		board := l.view(tx)
+		if board.members == nil {
+			return nil
+		}
+
+		for _, memberID := range memberIDs {
+			if err := board.remove(memberID); err != nil {
+				return err
+			}
+
+			if err := board.metadata.Delete([]byte(memberID)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// listRange get members from index begin to end in ascending order of keys, in order.
+func (l *TypedBoltLeaderboard[ID, S]) listRange(board *boltBoard, begin, end int, order Order) ([]*TypedMember[ID, S], error) {
+	listMember := make([]*TypedMember[ID, S], 0, maxInt(end-begin, 0))
+	first := board.counts.At(begin, board.isKey)
+	if first == nil {
+		return listMember, nil
+	}
+
+	cursor := board.keys.Cursor()
+	for key, _ := cursor.Seek(first); key != nil && len(listMember) < end-begin; key, _ = cursor.Next() {
+		member, err := l.decodeMember(board, key)
+		if err != nil {
+			return nil, err
+		}
+		listMember = append(listMember, member)
+	}
+
+	if order != OrderAsc {
+		for left, right := 0, len(listMember)-1; left < right; left, right = left+1, right-1 {
+			listMember[left], listMember[right] = listMember[right], listMember[left]
+		}
+	}
+
+	return listMember, nil
+}
+
+// listOffset get limit members from offset in order among members from index first to last in ascending order of keys.
+func (l *TypedBoltLeaderboard[ID, S]) listOffset(board *boltBoard, first, last, offset, limit int, order Order) ([]*TypedMember[ID, S], Cursor, error) {
+	total := last - first
+	begin := offset
+	end := minInt(offset+limit, total)
+	if order != OrderAsc {
+		begin, end = total-end, total-offset
+	}
+
+	listMember, err := l.listRange(board, first+maxInt(begin, 0), first+end, order)
+	if err != nil {
+		return nil, Cursor{}, err
+	}
+
+	return listMember, Cursor{
+		Begin: offset,
+		End:   offset + len(listMember),
+		Total: total,
+	}, nil
+}
+
+// List get list member with offset, limit and order in leaderboard
+func (l *TypedBoltLeaderboard[ID, S]) List(ctx context.Context, offset, limit int, order Order) ([]*TypedMember[ID, S], Cursor, error) {
+	var listMember []*TypedMember[ID, S]
+	var cursor Cursor
+	err := l.db.View(func(tx *bolt.Tx) error {
+		board := l.view(tx)
+
+		var err error
+		listMember, cursor, err = l.listOffset(board, 0, board.counts.Total(), offset, limit, order)
+		return err
+	})
+
+	return listMember, cursor, err
+}
+
+// scoreRange get index of the first member in range [min, max] and of the one after the last member in it.
+func (l *TypedBoltLeaderboard[ID, S]) scoreRange(board *boltBoard, min, max ScoreBound) (int, int, error) {
+	minScore, minExclusive, err := parseScoreBound(min)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	maxScore, maxExclusive, err := parseScoreBound(max)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	first := board.countScores(minScore, minExclusive)
+	last := board.countScores(maxScore, !maxExclusive)
+	if last < first {
+		last = first
+	}
+
+	return first, last, nil
+}
+
+// ListByScore get list member whose score is in range [min, max] with offset, limit and order in leaderboard.
+// Offset and cursor are counted from the first member in the range.
+func (l *TypedBoltLeaderboard[ID, S]) ListByScore(ctx context.Context, min, max ScoreBound, offset, limit int, order Order) ([]*TypedMember[ID, S], Cursor, error) {
+	var listMember []*TypedMember[ID, S]
+	var cursor Cursor
+	err := l.db.View(func(tx *bolt.Tx) error {
+		board := l.view(tx)
+		first, last, err := l.scoreRange(board, min, max)
+		if err != nil {
+			return err
+		}
+
+		listMember, cursor, err = l.listOffset(board, first, last, offset, limit, order)
+		return err
+	})
+
+	return listMember, cursor, err
+}
+
+// GetAround get list member around another member with limit and order.
+// It returns ErrMemberNotFound if member is not in leaderboard.
+func (l *TypedBoltLeaderboard[ID, S]) GetAround(ctx context.Context, id ID, limit int, order Order) ([]*TypedMember[ID, S], Cursor, error) {
+	memberID, err := l.codec.EncodeID(id)
+	if err != nil {
+		return nil, Cursor{}, err
+	}
+
+	var listMember []*TypedMember[ID, S]
+	var cursor Cursor
+	err = l.db.View(func(tx *bolt.Tx) error {
+		board := l.view(tx)
+		key := board.key(memberID)
+		if key == nil {
+			return ErrMemberNotFound
+		}
+
+		total := board.counts.Total()
+		rank := board.index(key)
+		if order != OrderAsc {
+			rank = total - 1 - rank
+		}
+
+		var err error
+		listMember, cursor, err = l.listOffset(board, 0, total, cursorAround(rank, limit, total), limit, order)
+		return err
+	})
+	if err != nil {
+		return nil, Cursor{}, err
+	}
+
+	return listMember, cursor, nil
+}
+
+// GetRank get rank of a member.
+// It returns ErrMemberNotFound if member is not in leaderboard.
+func (l *TypedBoltLeaderboard[ID, S]) GetRank(ctx context.Context, id ID) (int, error) {
+	member, err := l.GetMember(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+
+	return member.Rank, nil
+}
+
+// GetPercentile get percentile of a member in leaderboard, it is in range (0, 100] and smaller is better.
+// With RankDense it is the rank of member over number of distinct scores, otherwise it is the rank over number of members.
+// It returns ErrMemberNotFound if member is not in leaderboard.
+func (l *TypedBoltLeaderboard[ID, S]) GetPercentile(ctx context.Context, id ID) (float64, error) {
+	memberID, err := l.codec.EncodeID(id)
+	if err != nil {
+		return 0, err
+	}
+
+	var value float64
+	err = l.db.View(func(tx *bolt.Tx) error {
+		board := l.view(tx)
+		key := board.key(memberID)
+		if key == nil {
+			return ErrMemberNotFound
+		}
+
+		total := board.counts.Total()
+		if l.opts.rankingScheme() == RankDense {
+			total = board.scoreCounts.Total()
+		}
+
+		value = percentile(board.rank(key, l.opts.rankingScheme()), total)
+		return nil
+	})
+
+	return value, err
+}
+
+// GetMember get score and rank of a member in one call.
+// It returns ErrMemberNotFound if member is not in leaderboard.
+func (l *TypedBoltLeaderboard[ID, S]) GetMember(ctx context.Context, id ID) (*TypedMember[ID, S], error) {
+	members, err := l.GetMembers(ctx, []ID{id})
+	if err != nil {
+		return nil, err
+	}
+
+	if members[0] == nil {
+		return nil, ErrMemberNotFound
+	}
+
+	return members[0], nil
+}
+
+// GetMembers get score and rank of a list of members in one call.
+// Members are returned in the same order as ids, a member is nil if its id is not in leaderboard.
+func (l *TypedBoltLeaderboard[ID, S]) GetMembers(ctx context.Context, ids []ID) ([]*TypedMember[ID, S], error) {
+	memberIDs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		memberID, err := l.codec.EncodeID(id)
+		if err != nil {
+			return nil, err
+		}
+		memberIDs = append(memberIDs, memberID)
+	}
+
+	listMember := make([]*TypedMember[ID, S], len(ids))
+	err := l.db.View(func(tx *bolt.Tx) error {
+		board := l.view(tx)
+		for idx, id := range ids {
+			key := board.key(memberIDs[idx])
+			if key == nil {
+				continue
+			}
+
+			member, err := l.member(board, id, key)
+			if err != nil {
+				return err
+			}
+			listMember[idx] = member
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return listMember, nil
+}
+
+// listSubset get members of ids which are in leaderboard with their rank among them, ordered by rank.
+func (l *TypedBoltLeaderboard[ID, S]) listSubset(ids []ID) ([]*TypedMember[ID, S], error) {
+	memberIDs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		memberID, err := l.codec.EncodeID(id)
+		if err != nil {
+			return nil, err
+		}
+		memberIDs = append(memberIDs, memberID)
+	}
+
+	var listMember []*TypedMember[ID, S]
+	err := l.db.View(func(tx *bolt.Tx) error {
+		board := l.view(tx)
+		keys := make([][]byte, 0, len(memberIDs))
+		found := make(map[string]bool, len(memberIDs))
+		for _, memberID := range memberIDs {
+			if key := board.key(memberID); key != nil && !found[memberID] {
+				found[memberID] = true
+				keys = append(keys, key)
+			}
+		}
+
+		sort.Slice(keys, func(i, j int) bool {
+			return bytes.Compare(keys[i], keys[j]) > 0
+		})
+
+		listMember = make([]*TypedMember[ID, S], 0, len(keys))
+		for _, key := range keys {
+			member, err := l.decodeMember(board, key)
+			if err != nil {
+				return err
+			}
+			listMember = append(listMember, member)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rankSubset(listMember, l.opts.rankingScheme())
+	return listMember, nil
+}
+
+// ListSubset get members of ids with order, for example to rank a member among its friends.
+// Members are ranked among ids like Options.RankingScheme ranks them in leaderboard, Member.SubsetRank is
+// their rank among ids and Member.Rank is their rank in leaderboard. Ids which are not in leaderboard are ignored.
+func (l *TypedBoltLeaderboard[ID, S]) ListSubset(ctx context.Context, ids []ID, order Order) ([]*TypedMember[ID, S], error) {
+	listMember, err := l.listSubset(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	if order == OrderAsc {
+		for left, right := 0, len(listMember)-1; left < right; left, right = left+1, right-1 {
+			listMember[left], listMember[right] = listMember[right], listMember[left]
+		}
+	}
+
+	return listMember, nil
+}
+
+// GetRankInSubset get rank of a member among itself and members of ids like ListSubset.
+// It returns ErrMemberNotFound if member is not in leaderboard.
+func (l *TypedBoltLeaderboard[ID, S]) GetRankInSubset(ctx context.Context, id ID, ids []ID) (int, error) {
+	memberID, err := l.codec.EncodeID(id)
+	if err != nil {
+		return 0, err
+	}
+
+	subset := make([]ID, 0, len(ids)+1)
+	subset = append(subset, id)
+	subset = append(subset, ids...)
+
+	listMember, err := l.listSubset(subset)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, member := range listMember {
+		if encoded, _ := l.codec.EncodeID(member.ID); encoded == memberID {
+			return member.SubsetRank, nil
+		}
+	}
+
+	return 0, ErrMemberNotFound
+}
+
+// Count get number of members in leaderboard
+func (l *TypedBoltLeaderboard[ID, S]) Count(ctx context.Context) (int, error) {
+	total := 0
+	err := l.db.View(func(tx *bolt.Tx) error {
+		total = l.view(tx).counts.Total()
+		return nil
+	})
+
+	return total, err
+}
+
+// CountByScore get number of members whose score is in range [min, max]
+func (l *TypedBoltLeaderboard[ID, S]) CountByScore(ctx context.Context, min, max ScoreBound) (int, error) {
+	total := 0
+	err := l.db.View(func(tx *bolt.Tx) error {
+		first, last, err := l.scoreRange(l.view(tx), min, max)
+		total = last - first
+		return err
+	})
+
+	return total, err
+}
+
+// Clean clear all data of leaderboard in database
+func (l *TypedBoltLeaderboard[ID, S]) Clean(ctx context.Context) error {
+	return l.db.Update(func(tx *bolt.Tx) error {
+		err := tx.DeleteBucket(l.bucket)
+		if errors.Is(err, bolt.ErrBucketNotFound) {
+			return nil
+		}
+
+		return err
+	})
+}
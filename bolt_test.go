@@ -0,0 +1,99 @@
+package goleaderboard
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+func openBolt(t *testing.T, path string) *bolt.DB {
+	db, err := bolt.Open(path, 0600, nil)
+	if err != nil {
+		t.Fatal("failed to open bolt database", err.Error())
+	}
+
+	return db
+}
+
+func TestBoltLeaderboardRestart(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "leaderboard.db")
+	opts := &Options{RankingScheme: RankStandardCompetition, IncludeMetadata: true}
+
+	db := openBolt(t, path)
+	leaderboard := NewBoltLeaderBoard(db, "test", opts)
+	addMember(t, ctx, leaderboard, "P1", 10)
+	addMember(t, ctx, leaderboard, "P2", 20)
+	addMember(t, ctx, leaderboard, "P3", 20)
+	if err := leaderboard.AddMemberWithMetadata(ctx, "P4", 5, map[string]string{"name": "Duy"}); err != nil {
+		t.Fatal("failed to add member", err.Error())
+	}
+
+	if err := db.Close(); err != nil {
+		t.Fatal("failed to close bolt database", err.Error())
+	}
+
+	db = openBolt(t, path)
+	defer db.Close()
+
+	leaderboard = NewBoltLeaderBoard(db, "test", opts)
+	getRank(t, ctx, leaderboard, "P3", 1)
+	getRank(t, ctx, leaderboard, "P1", 3)
+
+	member, err := leaderboard.GetMember(ctx, "P4")
+	if err != nil || member.Rank != 4 || member.Metadata["name"] != "Duy" {
+		t.Errorf("Error in get member after restart\nExpected: rank #4 with metadata\nReceived: %+v, %v", member, err)
+	}
+}
+
+func TestBoltLeaderboardTieBreak(t *testing.T) {
+	defer func() {
+		now = time.Now
+	}()
+
+	ctx := context.Background()
+	db := openBolt(t, filepath.Join(t.TempDir(), "leaderboard.db"))
+	defer db.Close()
+
+	leaderboard := NewBoltLeaderBoard(db, "test", &Options{TieBreak: TieBreakEarliest})
+	clock := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	for idx, id := range []string{"P3", "P1", "P2"} {
+		setClock(t, clock.Add(time.Duration(idx)*time.Second))
+		addMember(t, ctx, leaderboard, id, 10)
+	}
+
+	getRank(t, ctx, leaderboard, "P3", 1)
+	getRank(t, ctx, leaderboard, "P1", 2)
+	getRank(t, ctx, leaderboard, "P2", 3)
+}
+
+func TestBoltLeaderboardLifeTime(t *testing.T) {
+	defer func() {
+		now = time.Now
+	}()
+
+	ctx := context.Background()
+	db := openBolt(t, filepath.Join(t.TempDir(), "leaderboard.db"))
+	defer db.Close()
+
+	clock := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	setClock(t, clock)
+
+	leaderboard := NewBoltLeaderBoard(db, "test", &Options{LifeTime: time.Hour})
+	addMember(t, ctx, leaderboard, "P1", 10)
+
+	setClock(t, clock.Add(time.Hour))
+	if total, err := leaderboard.Count(ctx); err != nil || total != 0 {
+		t.Errorf("Error in count expired leaderboard\nExpected: 0\nReceived: %v, %v", total, err)
+	}
+
+	addMember(t, ctx, leaderboard, "P2", 5)
+	getRank(t, ctx, leaderboard, "P2", 1)
+	if _, err := leaderboard.GetRank(ctx, "P1"); !errors.Is(err, ErrMemberNotFound) {
+		t.Errorf("Error in get rank of expired member\nExpected: %v\nReceived: %v", ErrMemberNotFound, err)
+	}
+}
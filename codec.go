@@ -0,0 +1,82 @@
+package goleaderboard
+
+import (
+	"encoding"
+	"fmt"
+	"strconv"
+)
+
+// IDCodec converts ids of members to strings stored in Redis and back, so ids keep their type when they are listed.
+// Encoding must be one-to-one, different ids must not have the same string.
+type IDCodec[ID comparable] interface {
+	EncodeID(id ID) (string, error)
+	DecodeID(s string) (ID, error)
+}
+
+// StringCodec stores ids which are strings as is.
+type StringCodec struct{}
+
+func (StringCodec) EncodeID(id string) (string, error) {
+	return id, nil
+}
+
+func (StringCodec) DecodeID(s string) (string, error) {
+	return s, nil
+}
+
+// Int64Codec stores ids which are int64 in base 10.
+type Int64Codec struct{}
+
+func (Int64Codec) EncodeID(id int64) (string, error) {
+	return strconv.FormatInt(id, 10), nil
+}
+
+func (Int64Codec) DecodeID(s string) (int64, error) {
+	id, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("goleaderboard: invalid int64 id %q: %w", s, err)
+	}
+
+	return id, nil
+}
+
+// TextCodec stores ids which implement encoding.TextMarshaler by their text, for example uuid.UUID:
+//
+//	codec := goleaderboard.TextCodec[uuid.UUID, *uuid.UUID]{}
+type TextCodec[ID interface {
+	comparable
+	encoding.TextMarshaler
+}, PID interface {
+	*ID
+	encoding.TextUnmarshaler
+}] struct{}
+
+func (TextCodec[ID, PID]) EncodeID(id ID) (string, error) {
+	text, err := id.MarshalText()
+	if err != nil {
+		return "", err
+	}
+
+	return string(text), nil
+}
+
+func (TextCodec[ID, PID]) DecodeID(s string) (ID, error) {
+	var id ID
+	if err := PID(&id).UnmarshalText([]byte(s)); err != nil {
+		return id, fmt.Errorf("goleaderboard: invalid id %q: %w", s, err)
+	}
+
+	return id, nil
+}
+
+// AnyCodec stores ids of any type by their default format, ids are decoded as strings.
+// It is the codec of leaderboards created by NewLeaderBoard.
+type AnyCodec struct{}
+
+func (AnyCodec) EncodeID(id interface{}) (string, error) {
+	return fmt.Sprintf("%v", id), nil
+}
+
+func (AnyCodec) DecodeID(s string) (interface{}, error) {
+	return s, nil
+}
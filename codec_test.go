@@ -0,0 +1,121 @@
+package goleaderboard
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// regionID is an id implementing encoding.TextMarshaler like uuid.UUID.
+type regionID struct {
+	Region string
+	Number int
+}
+
+func (id regionID) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("%s-%d", id.Region, id.Number)), nil
+}
+
+func (id *regionID) UnmarshalText(text []byte) error {
+	idx := strings.LastIndex(string(text), "-")
+	if idx < 0 {
+		return fmt.Errorf("missing region of %q", text)
+	}
+
+	id.Region = string(text[:idx])
+	_, err := fmt.Sscan(string(text[idx+1:]), &id.Number)
+	return err
+}
+
+func TestTypedLeaderboard(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	testCases := []struct {
+		allowSameRank bool
+	}{
+		{
+			allowSameRank: false,
+		},
+		{
+			allowSameRank: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		ctx := context.Background()
+		leaderboard := NewTypedLeaderBoard[int64, int](redisClient, "test", Int64Codec{}, &Options{AllowSameRank: tc.allowSameRank})
+		defer clean(t, ctx, leaderboard)
+
+		err := leaderboard.AddMembers(ctx, []TypedMember[int64, int]{
+			{ID: 42, Score: 30},
+			{ID: 7, Score: 20},
+			{ID: -1, Score: 10},
+		})
+		if err != nil {
+			t.Error("failed to add members", err.Error())
+			return
+		}
+
+		members, _, err := leaderboard.List(ctx, 0, 3, OrderDesc)
+		if err != nil {
+			t.Error("failed to list members", err.Error())
+			return
+		}
+
+		expectedIDs := []int64{42, 7, -1}
+		for idx, member := range members {
+			if member.ID != expectedIDs[idx] {
+				t.Errorf("Error in list member with int64 id, same rank %v\nExpected: %v\nReceived: %v", tc.allowSameRank, expectedIDs[idx], member.ID)
+			}
+		}
+
+		members, _, err = leaderboard.GetAround(ctx, 7, 1, OrderDesc)
+		if err != nil {
+			t.Error("failed to get around member", err.Error())
+			return
+		}
+
+		if len(members) != 1 || members[0].ID != 7 || members[0].Rank != 2 {
+			t.Errorf("Error in get around member with int64 id, same rank %v\nExpected: member 7 at rank #2\nReceived: %+v", tc.allowSameRank, members)
+		}
+		clean(t, ctx, leaderboard)
+	}
+}
+
+func TestTextCodec(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	ctx := context.Background()
+	leaderboard := NewTypedLeaderBoard[regionID, int](redisClient, "test", TextCodec[regionID, *regionID]{}, nil)
+	defer clean(t, ctx, leaderboard)
+
+	first := regionID{Region: "eu-west", Number: 1}
+	second := regionID{Region: "us-east", Number: 2}
+	if err := leaderboard.AddMember(ctx, first, 20); err != nil {
+		t.Fatal("failed to add member", err.Error())
+	}
+	if err := leaderboard.AddMember(ctx, second, 10); err != nil {
+		t.Fatal("failed to add member", err.Error())
+	}
+
+	members, _, err := leaderboard.ListByScore(ctx, MinScore, MaxScore, 0, 2, OrderAsc)
+	if err != nil {
+		t.Fatal("failed to list members by score", err.Error())
+	}
+
+	if len(members) != 2 || members[0].ID != second || members[1].ID != first {
+		t.Errorf("Error in list member with text id\nExpected: %v, %v\nReceived: %+v", second, first, members)
+	}
+
+	list, err := leaderboard.GetMembers(ctx, []regionID{first, {Region: "ap", Number: 3}})
+	if err != nil {
+		t.Fatal("failed to get members", err.Error())
+	}
+
+	if list[0] == nil || list[0].ID != first || list[0].Rank != 1 || list[1] != nil {
+		t.Errorf("Error in get members with text id\nExpected: %v at rank #1 and nil\nReceived: %+v", first, list)
+	}
+}
@@ -0,0 +1,309 @@
+package goleaderboard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrSumCompositeScore is returned when scores of a composite leaderboard are updated by UpdateSum.
+var ErrSumCompositeScore = errors.New("goleaderboard: composite scores can not be summed")
+
+// optionsLeaderboard is a leaderboard whose configs can be checked, leaderboards of this package implement it.
+type optionsLeaderboard interface {
+	options() *Options
+}
+
+// Criterion is a criterion of composite scores, its values are integers in range [0, 2^Bits).
+// Order is the way to rank members by it, OrderDesc ranks the higher value higher and OrderAsc the lower one,
+// for example time to finish a game.
+type Criterion struct {
+	Bits  uint
+	Order Order
+}
+
+// CompositeMember is a member of a composite leaderboard, Scores are values of criteria in order.
+type CompositeMember[ID comparable] struct {
+	ID     ID
+	Scores []int64
+	Rank   int
+}
+
+// CompositeLeaderboard ranks members by several criteria, by the first criterion then by the next one when it is equal.
+// Values of criteria are packed into one int64 score of the underlying leaderboard,
+// so the sum of Bits of criteria can not be greater than 53.
+type CompositeLeaderboard[ID comparable] struct {
+	board    TypedLeaderboard[ID, int64]
+	criteria []Criterion
+}
+
+// NewCompositeLeaderBoard create a composite leaderboard storing packed scores in board.
+// It returns ErrSumCompositeScore if Options.UpdatePolicy of board is UpdateSum, because summed packed scores
+// would corrupt values of criteria. It returns an error if the sum of Bits is greater than 53, or than 22 when board
+// breaks ties by time.
+func NewCompositeLeaderBoard[ID comparable](board TypedLeaderboard[ID, int64], criteria ...Criterion) (*CompositeLeaderboard[ID], error) {
+	maxBits := uint(53)
+	if board, ok := board.(optionsLeaderboard); ok {
+		opts := board.options()
+		if opts.updatePolicy() == UpdateSum {
+			return nil, ErrSumCompositeScore
+		}
+
+		// packed scores must not be greater than MaxTieBreakScore
+		if opts.useTieBreak() {
+			maxBits = maxTieBreakBits
+		}
+	}
+
+	var bits uint
+	for _, criterion := range criteria {
+		bits += criterion.Bits
+	}
+
+	if len(criteria) == 0 || bits > maxBits {
+		return nil, fmt.Errorf("goleaderboard: composite scores need 1 to %v bits, got %v", maxBits, bits)
+	}
+
+	return &CompositeLeaderboard[ID]{
+		board:    board,
+		criteria: criteria,
+	}, nil
+}
+
+// pack convert values of criteria to one score, the first criterion is in the highest bits.
+// Values of criteria ranked by OrderAsc are reversed, so a higher score is always ranked higher.
+func (l *CompositeLeaderboard[ID]) pack(scores []int64) (int64, error) {
+	if len(scores) != len(l.criteria) {
+		return 0, fmt.Errorf("goleaderboard: composite scores need %v values, got %v", len(l.criteria), len(scores))
+	}
+
+	var score int64
+	for idx, criterion := range l.criteria {
+		max := int64(1)<<criterion.Bits - 1
+		value := scores[idx]
+		if value < 0 || value > max {
+			return 0, ErrScoreOutOfRange
+		}
+
+		if criterion.Order == OrderAsc {
+			value = max - value
+		}
+		score = score<<criterion.Bits | value
+	}
+
+	return score, nil
+}
+
+// scoreRange convert bounds of the first criterion to bounds of packed scores, which cover any values of the next
+// criteria. Bounds are swapped if the first criterion is ranked by OrderAsc, because its values are reversed.
+func (l *CompositeLeaderboard[ID]) scoreRange(min, max ScoreBound) (ScoreBound, ScoreBound, error) {
+	minScore, minExclusive, err := parseScoreBound(min)
+	if err != nil {
+		return "", "", err
+	}
+
+	maxScore, maxExclusive, err := parseScoreBound(max)
+	if err != nil {
+		return "", "", err
+	}
+
+	// values of criteria are integers, so bounds are rounded to the values in range
+	first := l.criteria[0]
+	maxValue := int64(1)<<first.Bits - 1
+	low := math.Max(math.Ceil(minScore), 0)
+	if minExclusive && low == minScore {
+		low++
+	}
+	high := math.Min(math.Floor(maxScore), float64(maxValue))
+	if maxExclusive && high == maxScore {
+		high--
+	}
+
+	if low > high {
+		// packed scores are never negative, so nothing is in range
+		return Inclusive(-1), Inclusive(-1), nil
+	}
+
+	lowValue, highValue := int64(low), int64(high)
+	if first.Order == OrderAsc {
+		lowValue, highValue = maxValue-highValue, maxValue-lowValue
+	}
+
+	var bits uint
+	for _, criterion := range l.criteria[1:] {
+		bits += criterion.Bits
+	}
+
+	return Inclusive(lowValue << bits), Inclusive(highValue<<bits | (int64(1)<<bits - 1)), nil
+}
+
+// unpack convert a score to values of criteria.
+func (l *CompositeLeaderboard[ID]) unpack(score int64) []int64 {
+	scores := make([]int64, len(l.criteria))
+	for idx := len(l.criteria) - 1; idx >= 0; idx-- {
+		criterion := l.criteria[idx]
+		max := int64(1)<<criterion.Bits - 1
+		value := score & max
+		score >>= criterion.Bits
+
+		if criterion.Order == OrderAsc {
+			value = max - value
+		}
+		scores[idx] = value
+	}
+
+	return scores
+}
+
+func (l *CompositeLeaderboard[ID]) unpackMember(member *TypedMember[ID, int64]) *CompositeMember[ID] {
+	if member == nil {
+		return nil
+	}
+
+	return &CompositeMember[ID]{
+		ID:     member.ID,
+		Scores: l.unpack(member.Score),
+		Rank:   member.Rank,
+	}
+}
+
+func (l *CompositeLeaderboard[ID]) unpackMembers(members []*TypedMember[ID, int64]) []*CompositeMember[ID] {
+	listMember := make([]*CompositeMember[ID], 0, len(members))
+	for _, member := range members {
+		listMember = append(listMember, l.unpackMember(member))
+	}
+
+	return listMember
+}
+
+// AddMember add a member with values of criteria to leaderboard.
+func (l *CompositeLeaderboard[ID]) AddMember(ctx context.Context, id ID, scores []int64) error {
+	score, err := l.pack(scores)
+	if err != nil {
+		return err
+	}
+
+	return l.board.AddMember(ctx, id, score)
+}
+
+// AddMembers add a list of members with values of criteria to leaderboard in one call, field Rank of members is ignored.
+func (l *CompositeLeaderboard[ID]) AddMembers(ctx context.Context, members []CompositeMember[ID]) error {
+	listMember := make([]TypedMember[ID, int64], 0, len(members))
+	for _, member := range members {
+		score, err := l.pack(member.Scores)
+		if err != nil {
+			return err
+		}
+
+		listMember = append(listMember, TypedMember[ID, int64]{ID: member.ID, Score: score})
+	}
+
+	return l.board.AddMembers(ctx, listMember)
+}
+
+// UpdateMember add a member with values of criteria like AddMember, but update its scores by policy.
+// UpdateKeepHighest keeps the scores which are ranked higher, it returns ErrSumCompositeScore with UpdateSum.
+func (l *CompositeLeaderboard[ID]) UpdateMember(ctx context.Context, id ID, scores []int64, policy UpdatePolicy) (bool, error) {
+	if policy == UpdateSum {
+		return false, ErrSumCompositeScore
+	}
+
+	score, err := l.pack(scores)
+	if err != nil {
+		return false, err
+	}
+
+	return l.board.UpdateMember(ctx, id, score, policy)
+}
+
+// RemoveMember remove members from leaderboard by their ids.
+func (l *CompositeLeaderboard[ID]) RemoveMember(ctx context.Context, ids ...ID) error {
+	return l.board.RemoveMember(ctx, ids...)
+}
+
+// List get list member with offset, limit and order in leaderboard
+func (l *CompositeLeaderboard[ID]) List(ctx context.Context, offset, limit int, order Order) ([]*CompositeMember[ID], Cursor, error) {
+	members, cursor, err := l.board.List(ctx, offset, limit, order)
+	if err != nil {
+		return nil, Cursor{}, err
+	}
+
+	return l.unpackMembers(members), cursor, nil
+}
+
+// ListByScore get list member whose value of the first criterion is in range from min to max,
+// with offset, limit and order like List.
+func (l *CompositeLeaderboard[ID]) ListByScore(ctx context.Context, min, max ScoreBound, offset, limit int, order Order) ([]*CompositeMember[ID], Cursor, error) {
+	minScore, maxScore, err := l.scoreRange(min, max)
+	if err != nil {
+		return nil, Cursor{}, err
+	}
+
+	members, cursor, err := l.board.ListByScore(ctx, minScore, maxScore, offset, limit, order)
+	if err != nil {
+		return nil, Cursor{}, err
+	}
+
+	return l.unpackMembers(members), cursor, nil
+}
+
+// GetAround get list member around another member with limit and order
+func (l *CompositeLeaderboard[ID]) GetAround(ctx context.Context, id ID, limit int, order Order) ([]*CompositeMember[ID], Cursor, error) {
+	members, cursor, err := l.board.GetAround(ctx, id, limit, order)
+	if err != nil {
+		return nil, Cursor{}, err
+	}
+
+	return l.unpackMembers(members), cursor, nil
+}
+
+// GetRank get rank of a member.
+func (l *CompositeLeaderboard[ID]) GetRank(ctx context.Context, id ID) (int, error) {
+	return l.board.GetRank(ctx, id)
+}
+
+// GetPercentile get percentile of a member in leaderboard.
+func (l *CompositeLeaderboard[ID]) GetPercentile(ctx context.Context, id ID) (float64, error) {
+	return l.board.GetPercentile(ctx, id)
+}
+
+// GetMember get values of criteria and rank of a member in one call.
+func (l *CompositeLeaderboard[ID]) GetMember(ctx context.Context, id ID) (*CompositeMember[ID], error) {
+	member, err := l.board.GetMember(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return l.unpackMember(member), nil
+}
+
+// GetMembers get values of criteria and rank of a list of members in one call.
+func (l *CompositeLeaderboard[ID]) GetMembers(ctx context.Context, ids []ID) ([]*CompositeMember[ID], error) {
+	members, err := l.board.GetMembers(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	return l.unpackMembers(members), nil
+}
+
+// Count get number of members in leaderboard
+func (l *CompositeLeaderboard[ID]) Count(ctx context.Context) (int, error) {
+	return l.board.Count(ctx)
+}
+
+// CountByScore get number of members whose value of the first criterion is in range from min to max.
+func (l *CompositeLeaderboard[ID]) CountByScore(ctx context.Context, min, max ScoreBound) (int, error) {
+	minScore, maxScore, err := l.scoreRange(min, max)
+	if err != nil {
+		return 0, err
+	}
+
+	return l.board.CountByScore(ctx, minScore, maxScore)
+}
+
+// Clean clear all data of leaderboard
+func (l *CompositeLeaderboard[ID]) Clean(ctx context.Context) error {
+	return l.board.Clean(ctx)
+}
@@ -0,0 +1,172 @@
+package goleaderboard
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestCompositeLeaderboard(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	testCases := []struct {
+		allowSameRank bool
+	}{
+		{
+			allowSameRank: false,
+		},
+		{
+			allowSameRank: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		ctx := context.Background()
+		board := NewTypedLeaderBoard[string, int64](redisClient, "test", StringCodec{}, &Options{AllowSameRank: tc.allowSameRank})
+		// points desc, then wins desc, then time asc
+		leaderboard, err := NewCompositeLeaderBoard(
+			board,
+			Criterion{Bits: 20, Order: OrderDesc},
+			Criterion{Bits: 10, Order: OrderDesc},
+			Criterion{Bits: 20, Order: OrderAsc},
+		)
+		if err != nil {
+			t.Fatal("failed to create composite leaderboard", err.Error())
+		}
+		defer clean(t, ctx, board)
+
+		err = leaderboard.AddMembers(ctx, []CompositeMember[string]{
+			{ID: "PSlow", Scores: []int64{100, 5, 300}},
+			{ID: "PFast", Scores: []int64{100, 5, 200}},
+			{ID: "PWins", Scores: []int64{100, 7, 900}},
+			{ID: "PLow", Scores: []int64{50, 9, 10}},
+		})
+		if err != nil {
+			t.Error("failed to add members", err.Error())
+			return
+		}
+
+		members, _, err := leaderboard.List(ctx, 0, 4, OrderDesc)
+		if err != nil {
+			t.Error("failed to list members", err.Error())
+			return
+		}
+
+		expectedIDs := []string{"PWins", "PFast", "PSlow", "PLow"}
+		for idx, member := range members {
+			if member.ID != expectedIDs[idx] || member.Rank != idx+1 {
+				t.Errorf("Error in list composite member, same rank %v\nExpected: %v at rank #%v\nReceived: %+v", tc.allowSameRank, expectedIDs[idx], idx+1, member)
+			}
+		}
+
+		members, _, err = leaderboard.GetAround(ctx, "PSlow", 1, OrderDesc)
+		if err != nil {
+			t.Error("failed to get around member", err.Error())
+			return
+		}
+
+		if len(members) != 1 || !reflect.DeepEqual(members[0].Scores, []int64{100, 5, 300}) || members[0].Rank != 3 {
+			t.Errorf("Error in get around composite member, same rank %v\nExpected: PSlow with scores [100 5 300] at rank #3\nReceived: %+v", tc.allowSameRank, members)
+		}
+
+		members, cursor, err := leaderboard.ListByScore(ctx, Inclusive(100), MaxScore, 0, 4, OrderDesc)
+		if err != nil {
+			t.Error("failed to list members by score", err.Error())
+			return
+		}
+
+		if len(members) != 3 || members[0].ID != "PWins" || members[2].ID != "PSlow" || cursor.Total != 3 {
+			t.Errorf("Error in list composite member by score, same rank %v\nExpected: PWins, PFast and PSlow\nReceived: %+v, %+v", tc.allowSameRank, members, cursor)
+		}
+
+		total, err := leaderboard.CountByScore(ctx, MinScore, Exclusive(100))
+		if err != nil || total != 1 {
+			t.Errorf("Error in count composite member by score, same rank %v\nExpected: 1\nReceived: %v, %v", tc.allowSameRank, total, err)
+		}
+
+		changed, err := leaderboard.UpdateMember(ctx, "PFast", []int64{100, 5, 250}, UpdateKeepHighest)
+		if err != nil || changed {
+			t.Errorf("Error in keep best composite scores, same rank %v\nExpected: not changed\nReceived: %v, %v", tc.allowSameRank, changed, err)
+		}
+
+		_, err = leaderboard.UpdateMember(ctx, "PFast", []int64{1, 0, 0}, UpdateSum)
+		if !errors.Is(err, ErrSumCompositeScore) {
+			t.Errorf("Error in sum composite scores\nExpected: %v\nReceived: %v", ErrSumCompositeScore, err)
+		}
+
+		err = leaderboard.AddMember(ctx, "PHuge", []int64{1 << 20, 0, 0})
+		if !errors.Is(err, ErrScoreOutOfRange) {
+			t.Errorf("Error in add composite member with huge score\nExpected: %v\nReceived: %v", ErrScoreOutOfRange, err)
+		}
+		clean(t, ctx, board)
+	}
+}
+
+func TestNewCompositeLeaderboard(t *testing.T) {
+	_, err := NewCompositeLeaderBoard[string](nil, Criterion{Bits: 40}, Criterion{Bits: 14})
+	if err == nil {
+		t.Error("Error in create composite leaderboard with 54 bits\nExpected: error\nReceived: nil")
+	}
+
+	board := NewTypedMemoryLeaderBoard[string, int64](StringCodec{}, &Options{TieBreak: TieBreakEarliest})
+	_, err = NewCompositeLeaderBoard(board, Criterion{Bits: 20}, Criterion{Bits: 3})
+	if err == nil {
+		t.Error("Error in create composite leaderboard with 23 bits and tie break\nExpected: error\nReceived: nil")
+	}
+
+	if _, err := NewCompositeLeaderBoard(board, Criterion{Bits: 20}, Criterion{Bits: 2}); err != nil {
+		t.Errorf("Error in create composite leaderboard with 22 bits and tie break\nExpected: nil\nReceived: %v", err)
+	}
+}
+
+func TestCompositeLeaderboardUpdateSum(t *testing.T) {
+	board := NewTypedMemoryLeaderBoard[string, int64](StringCodec{}, &Options{UpdatePolicy: UpdateSum})
+	_, err := NewCompositeLeaderBoard(board, Criterion{Bits: 20, Order: OrderDesc})
+	if !errors.Is(err, ErrSumCompositeScore) {
+		t.Errorf("Error in create composite leaderboard summing scores\nExpected: %v\nReceived: %v", ErrSumCompositeScore, err)
+	}
+}
+
+func TestCompositeLeaderboardListByScoreAsc(t *testing.T) {
+	ctx := context.Background()
+	board := NewTypedMemoryLeaderBoard[string, int64](StringCodec{}, nil)
+	// time asc, then points desc
+	leaderboard, err := NewCompositeLeaderBoard(board, Criterion{Bits: 10, Order: OrderAsc}, Criterion{Bits: 10, Order: OrderDesc})
+	if err != nil {
+		t.Fatal("failed to create composite leaderboard", err.Error())
+	}
+
+	err = leaderboard.AddMembers(ctx, []CompositeMember[string]{
+		{ID: "PFast", Scores: []int64{30, 1}},
+		{ID: "PMiddle", Scores: []int64{40, 1023}},
+		{ID: "PSlow", Scores: []int64{50, 0}},
+	})
+	if err != nil {
+		t.Fatal("failed to add members", err.Error())
+	}
+
+	members, _, err := leaderboard.ListByScore(ctx, Inclusive(35), Inclusive(50), 0, 10, OrderDesc)
+	if err != nil || len(members) != 2 || members[0].ID != "PMiddle" || members[1].ID != "PSlow" {
+		t.Errorf("Error in list composite member by score ranked asc\nExpected: PMiddle and PSlow\nReceived: %+v, %v", members, err)
+	}
+
+	testCases := []struct {
+		min      ScoreBound
+		max      ScoreBound
+		expected int
+	}{
+		{Exclusive(30), MaxScore, 2},
+		{MinScore, Exclusive(40), 1},
+		{Inclusive(40), Inclusive(40), 1},
+		{Exclusive(50), MaxScore, 0},
+	}
+
+	for _, tc := range testCases {
+		total, err := leaderboard.CountByScore(ctx, tc.min, tc.max)
+		if err != nil || total != tc.expected {
+			t.Errorf("Error in count composite member by score in [%v, %v]\nExpected: %v\nReceived: %v, %v", tc.min, tc.max, tc.expected, total, err)
+		}
+	}
+}
@@ -15,7 +15,7 @@ func main() {
 		DB:       0,  // use default DB
 	})
 	leaderboard := goleaderboard.NewLeaderBoard(rdb, "test", &goleaderboard.Options{
-		AllowSameRank: true,
+		RankingScheme: goleaderboard.RankDense,
 	})
 
 	ctx := context.Background()
@@ -0,0 +1,83 @@
+package goleaderboard
+
+import (
+	"context"
+)
+
+// invalidLeaderboard is returned by constructors which don't return errors when a config has an unknown value,
+// every operation returns the error of config instead of using the default value.
+type invalidLeaderboard[ID comparable, S Score] struct {
+	err error
+}
+
+func (l *invalidLeaderboard[ID, S]) AddMember(ctx context.Context, id ID, score S) error {
+	return l.err
+}
+
+func (l *invalidLeaderboard[ID, S]) AddMemberWithMetadata(ctx context.Context, id ID, score S, metadata map[string]string) error {
+	return l.err
+}
+
+func (l *invalidLeaderboard[ID, S]) AddMembers(ctx context.Context, members []TypedMember[ID, S]) error {
+	return l.err
+}
+
+func (l *invalidLeaderboard[ID, S]) UpdateMember(ctx context.Context, id ID, score S, policy UpdatePolicy) (bool, error) {
+	return false, l.err
+}
+
+func (l *invalidLeaderboard[ID, S]) IncrementScore(ctx context.Context, id ID, delta S) (*TypedMember[ID, S], error) {
+	return nil, l.err
+}
+
+func (l *invalidLeaderboard[ID, S]) RemoveMember(ctx context.Context, ids ...ID) error {
+	return l.err
+}
+
+func (l *invalidLeaderboard[ID, S]) List(ctx context.Context, offset, limit int, order Order) ([]*TypedMember[ID, S], Cursor, error) {
+	return nil, Cursor{}, l.err
+}
+
+func (l *invalidLeaderboard[ID, S]) ListByScore(ctx context.Context, min, max ScoreBound, offset, limit int, order Order) ([]*TypedMember[ID, S], Cursor, error) {
+	return nil, Cursor{}, l.err
+}
+
+func (l *invalidLeaderboard[ID, S]) GetAround(ctx context.Context, id ID, limit int, order Order) ([]*TypedMember[ID, S], Cursor, error) {
+	return nil, Cursor{}, l.err
+}
+
+func (l *invalidLeaderboard[ID, S]) GetRank(ctx context.Context, id ID) (int, error) {
+	return 0, l.err
+}
+
+func (l *invalidLeaderboard[ID, S]) GetPercentile(ctx context.Context, id ID) (float64, error) {
+	return 0, l.err
+}
+
+func (l *invalidLeaderboard[ID, S]) GetMember(ctx context.Context, id ID) (*TypedMember[ID, S], error) {
+	return nil, l.err
+}
+
+func (l *invalidLeaderboard[ID, S]) GetMembers(ctx context.Context, ids []ID) ([]*TypedMember[ID, S], error) {
+	return nil, l.err
+}
+
+func (l *invalidLeaderboard[ID, S]) ListSubset(ctx context.Context, ids []ID, order Order) ([]*TypedMember[ID, S], error) {
+	return nil, l.err
+}
+
+func (l *invalidLeaderboard[ID, S]) GetRankInSubset(ctx context.Context, id ID, ids []ID) (int, error) {
+	return 0, l.err
+}
+
+func (l *invalidLeaderboard[ID, S]) Count(ctx context.Context) (int, error) {
+	return 0, l.err
+}
+
+func (l *invalidLeaderboard[ID, S]) CountByScore(ctx context.Context, min, max ScoreBound) (int, error) {
+	return 0, l.err
+}
+
+func (l *invalidLeaderboard[ID, S]) Clean(ctx context.Context) error {
+	return l.err
+}
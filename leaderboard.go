@@ -2,18 +2,136 @@ package goleaderboard
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
-	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-redis/redis/v8"
 )
 
+// ErrMemberNotFound is returned when a member is not in leaderboard.
+var ErrMemberNotFound = errors.New("goleaderboard: member not found")
+
+// ErrScoreOutOfRange is returned when a score can not be stored without losing precision.
+var ErrScoreOutOfRange = errors.New("goleaderboard: score is out of range")
+
+// RankingScheme is the way to rank members which have the same score.
+type RankingScheme string
+
+var (
+	// RankOrdinal gives every member a distinct rank, members with the same score are ranked by id (1, 2, 3, 4).
+	RankOrdinal RankingScheme = "ordinal"
+	// RankDense gives members with the same score the same rank, next score gets the next rank (1, 1, 2, 3).
+	RankDense RankingScheme = "dense"
+	// RankStandardCompetition gives members with the same score the same rank, then leaves a gap (1, 1, 3, 4).
+	RankStandardCompetition RankingScheme = "standard_competition"
+	// RankModifiedCompetition leaves the gap before members with the same score instead (1, 3, 3, 4).
+	RankModifiedCompetition RankingScheme = "modified_competition"
+)
+
+// UpdatePolicy is the way to update score of a member which is already in leaderboard.
+type UpdatePolicy string
+
+var (
+	// UpdateReplace always replaces score of member with the new one.
+	UpdateReplace UpdatePolicy = "replace"
+	// UpdateKeepHighest only replaces score of member with a higher one, for example to keep the personal best.
+	UpdateKeepHighest UpdatePolicy = "keep_highest"
+	// UpdateKeepLowest only replaces score of member with a lower one, for example to keep the fastest time.
+	UpdateKeepLowest UpdatePolicy = "keep_lowest"
+	// UpdateSum adds the new score to score of member.
+	UpdateSum UpdatePolicy = "sum"
+)
+
 // Options contains all configs for leaderboard
 type Options struct {
+	// Deprecated: use RankingScheme with RankDense instead, it is only used when RankingScheme is empty.
 	AllowSameRank bool
-	LifeTime      time.Duration
+	// RankingScheme is the way to rank members, default is RankOrdinal.
+	RankingScheme RankingScheme
+	// TieBreak is the way to order members with the same score when RankingScheme is RankOrdinal, default is TieBreakID.
+	// Ties are broken by whole seconds, members which reached the same score in the same second are ordered by id.
+	// With TieBreakEarliest or TieBreakLatest, scores must be integers whose absolute value is not greater than MaxTieBreakScore,
+	// which is 4,194,303, larger scores return ErrScoreOutOfRange in Redis.
+	TieBreak TieBreak
+	// UpdatePolicy is the way AddMember and AddMembers update score of members, default is UpdateReplace.
+	UpdatePolicy UpdatePolicy
+	// LifeTime is how long leaderboard is kept after the last write, 0 means it never expires.
+	// A LifeTime less than a second is a number of seconds like before it was used as a duration, so LifeTime: 60
+	// is kept for 60 seconds like LifeTime: 60 * time.Second.
+	LifeTime time.Duration
+	// IncludeMetadata is whether List, ListByScore, GetAround, GetMember and GetMembers return metadata of members.
+	IncludeMetadata bool
+	// ClusterKeys is whether keys in Redis have the name of leaderboard as hash tag, like "goleaderboard:{test}:rank_set",
+	// so they are in the same slot of Redis Cluster. Keys of an existing board change when it is set, default is false.
+	ClusterKeys bool
+}
+
+// keyName get name which keys of a leaderboard are named by, it has a hash tag if ClusterKeys is set.
+func (o *Options) keyName(name string) string {
+	if o.ClusterKeys {
+		return hashTag(name)
+	}
+
+	return name
+}
+
+// lifeTime get how long leaderboard is kept after the last write, LifeTime less than a second is a number of seconds.
+func (o *Options) lifeTime() time.Duration {
+	if o.LifeTime > 0 && o.LifeTime < time.Second {
+		return o.LifeTime * time.Second
+	}
+
+	return o.LifeTime
+}
+
+func (o *Options) rankingScheme() RankingScheme {
+	if o.RankingScheme != "" {
+		return o.RankingScheme
+	}
+
+	if o.AllowSameRank {
+		return RankDense
+	}
+
+	return RankOrdinal
+}
+
+// validate return an error if a config of options has an unknown value, for example a typo read from a config file,
+// which would otherwise be used like the default one.
+func (o *Options) validate() error {
+	switch o.RankingScheme {
+	case "", RankOrdinal, RankDense, RankStandardCompetition, RankModifiedCompetition:
+	default:
+		return fmt.Errorf("goleaderboard: unknown ranking scheme %q", o.RankingScheme)
+	}
+
+	if o.UpdatePolicy != "" {
+		return checkUpdatePolicy(o.UpdatePolicy)
+	}
+
+	return nil
+}
+
+// checkUpdatePolicy return an error if policy is not one of UpdateReplace, UpdateKeepHighest, UpdateKeepLowest
+// or UpdateSum.
+func checkUpdatePolicy(policy UpdatePolicy) error {
+	switch policy {
+	case UpdateReplace, UpdateKeepHighest, UpdateKeepLowest, UpdateSum:
+		return nil
+	}
+
+	return fmt.Errorf("goleaderboard: unknown update policy %q", policy)
+}
+
+func (o *Options) updatePolicy() UpdatePolicy {
+	if o.UpdatePolicy != "" {
+		return o.UpdatePolicy
+	}
+
+	return UpdateReplace
 }
 
 // Order is the way to sort leaderboard.
@@ -24,79 +142,186 @@ var (
 	OrderAsc  Order = "asc"
 )
 
+// ScoreBound is a bound of score range, it can be inclusive, exclusive or infinite.
+type ScoreBound string
+
+var (
+	MinScore ScoreBound = "-inf"
+	MaxScore ScoreBound = "+inf"
+)
+
+// Inclusive create a score bound which includes score.
+func Inclusive[S Score](score S) ScoreBound {
+	return ScoreBound(formatScore(score))
+}
+
+// Exclusive create a score bound which excludes score.
+func Exclusive[S Score](score S) ScoreBound {
+	return ScoreBound("(" + formatScore(score))
+}
+
 // Cursor mark the begin and end offset of list member in leaderboard
 type Cursor struct {
 	Begin int
 	End   int
+	// Total is the number of members which can be listed, it is the size of leaderboard or of the score range.
+	Total int
 }
 
-// Member is a member of leaderboard.
+// TypedMember is a member of leaderboard whose id has type ID and score has type S.
 // It is the main object of leaderboard.
-type Member struct {
-	ID    interface{}
-	Score int
+type TypedMember[ID comparable, S Score] struct {
+	ID    ID
+	Score S
 	Rank  int
+	// Metadata is stored by AddMemberWithMetadata or AddMembers, it is returned when Options.IncludeMetadata is set.
+	Metadata map[string]string
+	// SubsetRank is the rank of member among a subset of members, it is only set by ListSubset.
+	SubsetRank int
 }
 
-// Leaderboard is the representation of a leaderboard usage.
-type Leaderboard interface {
-	AddMember(ctx context.Context, id interface{}, score int) error
-	List(ctx context.Context, offset, limit int, order Order) ([]*Member, Cursor, error)
-	GetAround(ctx context.Context, id interface{}, limit int, order Order) ([]*Member, Cursor, error)
-	GetRank(ctx context.Context, id interface{}) (int, error)
+// Member is a member of leaderboard with an id of any type and an int score.
+// Ids of members listed from leaderboard are strings.
+type Member = TypedMember[interface{}, int]
+
+// TypedLeaderboard is the representation of a leaderboard usage whose member ids have type ID and scores have type S.
+type TypedLeaderboard[ID comparable, S Score] interface {
+	AddMember(ctx context.Context, id ID, score S) error
+	AddMemberWithMetadata(ctx context.Context, id ID, score S, metadata map[string]string) error
+	AddMembers(ctx context.Context, members []TypedMember[ID, S]) error
+	UpdateMember(ctx context.Context, id ID, score S, policy UpdatePolicy) (bool, error)
+	IncrementScore(ctx context.Context, id ID, delta S) (*TypedMember[ID, S], error)
+	RemoveMember(ctx context.Context, ids ...ID) error
+	List(ctx context.Context, offset, limit int, order Order) ([]*TypedMember[ID, S], Cursor, error)
+	ListByScore(ctx context.Context, min, max ScoreBound, offset, limit int, order Order) ([]*TypedMember[ID, S], Cursor, error)
+	GetAround(ctx context.Context, id ID, limit int, order Order) ([]*TypedMember[ID, S], Cursor, error)
+	GetRank(ctx context.Context, id ID) (int, error)
+	GetPercentile(ctx context.Context, id ID) (float64, error)
+	GetMember(ctx context.Context, id ID) (*TypedMember[ID, S], error)
+	GetMembers(ctx context.Context, ids []ID) ([]*TypedMember[ID, S], error)
+	ListSubset(ctx context.Context, ids []ID, order Order) ([]*TypedMember[ID, S], error)
+	GetRankInSubset(ctx context.Context, id ID, ids []ID) (int, error)
+	Count(ctx context.Context) (int, error)
+	CountByScore(ctx context.Context, min, max ScoreBound) (int, error)
 	Clean(ctx context.Context) error
 }
 
-// RedisLeaderboard defines a leaderboard stored in Redis, follows Leaderboard interface
-type RedisLeaderboard struct {
-	redisClient      *redis.Client
-	name             string
-	rankSet          string
-	memberScoreSet   string
-	addMemberScript  *redis.Script
-	listMemberScript *redis.Script
-	getRankScript    *redis.Script
-	getAroundScript  *redis.Script
-	opts             *Options
+// Leaderboard is the representation of a leaderboard usage with member ids of any type.
+type Leaderboard = TypedLeaderboard[interface{}, int]
+
+// TypedRedisLeaderboard defines a leaderboard stored in Redis whose member ids have type ID and scores have type S,
+// follows TypedLeaderboard interface.
+// Ids are stored as strings encoded by an IDCodec.
+type TypedRedisLeaderboard[ID comparable, S Score] struct {
+	redisClient                 redis.UniversalClient
+	name                        string
+	rankSet                     string
+	memberScoreSet              string
+	metadataHash                string
+	codec                       IDCodec[ID]
+	addMemberScript             *redis.Script
+	addMembersScript            *redis.Script
+	updateMembersScript         *redis.Script
+	incrementScoreScript        *redis.Script
+	incrementScoreOrdinalScript *redis.Script
+	removeMemberScript          *redis.Script
+	listMemberScript            *redis.Script
+	listMemberByScoreScript     *redis.Script
+	getRankScript               *redis.Script
+	getPercentileScript         *redis.Script
+	getAroundScript             *redis.Script
+	getMemberScript             *redis.Script
+	getMembersScript            *redis.Script
+	listSubsetScript            *redis.Script
+	opts                        *Options
 }
 
+// RedisLeaderboard defines a leaderboard stored in Redis with member ids of any type, follows Leaderboard interface
+type RedisLeaderboard = TypedRedisLeaderboard[interface{}, int]
+
 // NewLeaderBoard create a new leaderboard stored in Redis with specific name and configs.
-// You can see all supported config in type `Options`
-func NewLeaderBoard(redisClient *redis.Client, name string, opts *Options) Leaderboard {
+// redisClient can be a *redis.Client or a *redis.ClusterClient, which needs Options.ClusterKeys.
+// You can see all supported config in type `Options`, if a config has an unknown value every operation returns an error.
+func NewLeaderBoard(redisClient redis.UniversalClient, name string, opts *Options) Leaderboard {
+	return NewTypedLeaderBoard[interface{}, int](redisClient, name, AnyCodec{}, opts)
+}
+
+// NewTypedLeaderBoard create a new leaderboard stored in Redis whose member ids have type ID and are encoded by codec,
+// scores have type S.
+// You can see all supported config in type `Options`, if a config has an unknown value every operation returns an error.
+func NewTypedLeaderBoard[ID comparable, S Score](redisClient redis.UniversalClient, name string, codec IDCodec[ID], opts *Options) TypedLeaderboard[ID, S] {
+	if opts != nil {
+		if err := opts.validate(); err != nil {
+			return &invalidLeaderboard[ID, S]{err: err}
+		}
+	}
+
+	return newTypedRedisLeaderBoard[ID, S](redisClient, name, codec, opts)
+}
+
+func newTypedRedisLeaderBoard[ID comparable, S Score](redisClient redis.UniversalClient, name string, codec IDCodec[ID], opts *Options) *TypedRedisLeaderboard[ID, S] {
 	if opts == nil {
 		opts = &Options{
-			AllowSameRank: false,
+			RankingScheme: RankOrdinal,
 			LifeTime:      1 * time.Hour,
 		}
 	}
-	rankSet := generateRankSetName(name)
-	memberScoreSet := generateMemScoreSetName(name)
 
-	lb := &RedisLeaderboard{
+	lb := &TypedRedisLeaderboard[ID, S]{
 		redisClient:    redisClient,
 		name:           name,
-		rankSet:        rankSet,
-		memberScoreSet: memberScoreSet,
+		rankSet:        generateRankSetName(opts.keyName(name)),
+		memberScoreSet: generateMemScoreSetName(opts.keyName(name)),
+		metadataHash:   generateMetadataHashName(opts.keyName(name)),
+		codec:          codec,
 		opts:           opts,
 	}
 
 	lb.addMemberScript = redis.NewScript(initAddMemberScript())
+	lb.addMembersScript = redis.NewScript(initAddMembersScript())
+	lb.updateMembersScript = redis.NewScript(initUpdateMembersScript())
+	lb.incrementScoreScript = redis.NewScript(initIncrementScoreScript())
+	lb.incrementScoreOrdinalScript = redis.NewScript(initIncrementScoreOrdinalScript())
+	lb.removeMemberScript = redis.NewScript(initRemoveMemberScript())
 	lb.listMemberScript = redis.NewScript(initGetListMemberWithRankScript())
+	lb.listMemberByScoreScript = redis.NewScript(initGetListMemberByScoreWithRankScript())
 	lb.getRankScript = redis.NewScript(initGetRankScript())
+	lb.getPercentileScript = redis.NewScript(initGetPercentileScript())
 	lb.getAroundScript = redis.NewScript(initGetAroundScript())
+	lb.getMemberScript = redis.NewScript(initGetMemberScript())
+	lb.getMembersScript = redis.NewScript(initGetMembersScript())
+	lb.listSubsetScript = redis.NewScript(initListSubsetScript())
 
 	return lb
 }
 
-func (l *RedisLeaderboard) setTTL(ctx context.Context) {
+// keys get keys of leaderboard passed to scripts, they are in the same slot of Redis Cluster with Options.ClusterKeys.
+func (l *TypedRedisLeaderboard[ID, S]) keys() []string {
+	return []string{l.rankSet, l.memberScoreSet, l.metadataHash}
+}
+
+// allowSameRank reports whether members with the same score can have the same rank.
+// In that case members are stored in member score set and their distinct scores in rank set,
+// otherwise members are stored in rank set.
+func (l *TypedRedisLeaderboard[ID, S]) allowSameRank() bool {
+	return l.opts.rankingScheme() != RankOrdinal
+}
+
+// options get configs of leaderboard, so leaderboards built on it like CompositeLeaderboard can check them.
+func (l *TypedRedisLeaderboard[ID, S]) options() *Options {
+	return l.opts
+}
+
+func (l *TypedRedisLeaderboard[ID, S]) setTTL(ctx context.Context) {
 	if l.opts.LifeTime == 0 {
 		return
 	}
-	ttlDuration := l.opts.LifeTime * time.Second
+	ttlDuration := l.opts.lifeTime()
 	pipeline := l.redisClient.Pipeline()
-	pipeline.Expire(ctx, generateRankSetName(l.name), ttlDuration)
-	if l.opts.AllowSameRank {
-		pipeline.Expire(ctx, generateMemScoreSetName(l.name), ttlDuration)
+	pipeline.Expire(ctx, l.rankSet, ttlDuration)
+	pipeline.Expire(ctx, l.metadataHash, ttlDuration)
+	if l.allowSameRank() {
+		pipeline.Expire(ctx, l.memberScoreSet, ttlDuration)
 	}
 
 	if _, err := pipeline.Exec(ctx); err != nil {
@@ -104,46 +329,310 @@ func (l *RedisLeaderboard) setTTL(ctx context.Context) {
 	}
 }
 
-func (l *RedisLeaderboard) addMember(ctx context.Context, id interface{}, score int) error {
-	defer l.setTTL(ctx)
-	return l.redisClient.ZAdd(ctx, generateRankSetName(l.name), &redis.Z{
-		Score:  float64(score),
-		Member: id,
-	}).Err()
+// encodeIDs encode ids of members to strings stored in Redis.
+func (l *TypedRedisLeaderboard[ID, S]) encodeIDs(ids []ID) ([]interface{}, error) {
+	memberIDs := make([]interface{}, 0, len(ids))
+	for _, id := range ids {
+		memberID, err := l.codec.EncodeID(id)
+		if err != nil {
+			return nil, err
+		}
+		memberIDs = append(memberIDs, memberID)
+	}
+
+	return memberIDs, nil
+}
+
+// encodeMemberScores check scores of members can be stored like encodeScore.
+func (l *TypedRedisLeaderboard[ID, S]) encodeMemberScores(members []TypedMember[ID, S]) ([]float64, error) {
+	values := make([]float64, 0, len(members))
+	for _, member := range members {
+		value, err := l.encodeScore(member.Score)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+
+	return values, nil
 }
 
-func (l *RedisLeaderboard) addMemberSameRank(ctx context.Context, id interface{}, score int) error {
+// encodeMemberIDs encode ids of members like encodeIDs.
+func (l *TypedRedisLeaderboard[ID, S]) encodeMemberIDs(members []TypedMember[ID, S]) ([]interface{}, error) {
+	ids := make([]ID, 0, len(members))
+	for _, member := range members {
+		ids = append(ids, member.ID)
+	}
+
+	return l.encodeIDs(ids)
+}
+
+func (l *TypedRedisLeaderboard[ID, S]) addMember(ctx context.Context, id ID, score S, policy UpdatePolicy) (bool, error) {
+	changed, err := l.addMembers(ctx, []TypedMember[ID, S]{{ID: id, Score: score}}, policy)
+	return changed > 0, err
+}
+
+func (l *TypedRedisLeaderboard[ID, S]) addMemberSameRank(ctx context.Context, id ID, score S, policy UpdatePolicy) (bool, error) {
+	memberID, err := l.codec.EncodeID(id)
+	if err != nil {
+		return false, err
+	}
+
+	value, err := l.encodeScore(score)
+	if err != nil {
+		return false, err
+	}
+
 	defer l.setTTL(ctx)
-	_, err := l.addMemberScript.Run(ctx, l.redisClient, []string{l.name}, id, score).Result()
-	return err
+	changed, err := l.addMemberScript.Run(ctx, l.redisClient, l.keys(), memberID, value, string(policy), l.maxScore()).Int()
+	return changed > 0, parseScriptError(err)
 }
 
 // AddMember add a member with score to leaderboard.
 // It will automatically add member to the right position, if member was already in leaderboard, it will update the rank of this one.
-func (l *RedisLeaderboard) AddMember(ctx context.Context, id interface{}, score int) error {
-	if l.opts.AllowSameRank {
-		return l.addMemberSameRank(ctx, id, score)
+// Score of a member which was already in leaderboard is updated by Options.UpdatePolicy.
+func (l *TypedRedisLeaderboard[ID, S]) AddMember(ctx context.Context, id ID, score S) error {
+	_, err := l.UpdateMember(ctx, id, score, l.opts.updatePolicy())
+	return err
+}
+
+// UpdateMember add a member with score to leaderboard like AddMember, but update its score by policy instead of
+// Options.UpdatePolicy. It reports whether the stored score was changed, or returns an error if policy is unknown.
+func (l *TypedRedisLeaderboard[ID, S]) UpdateMember(ctx context.Context, id ID, score S, policy UpdatePolicy) (bool, error) {
+	if err := checkUpdatePolicy(policy); err != nil {
+		return false, err
 	}
 
-	return l.addMember(ctx, id, score)
+	if l.allowSameRank() {
+		return l.addMemberSameRank(ctx, id, score, policy)
+	}
+
+	return l.addMember(ctx, id, score, policy)
 }
 
-func (l *RedisLeaderboard) listMember(ctx context.Context, offset, limit int, order Order) ([]*Member, Cursor, error) {
-	cmd := l.redisClient.ZRevRangeWithScores
+func (l *TypedRedisLeaderboard[ID, S]) addMembers(ctx context.Context, members []TypedMember[ID, S], policy UpdatePolicy) (int, error) {
+	memberIDs, err := l.encodeMemberIDs(members)
+	if err != nil {
+		return 0, err
+	}
+
+	values, err := l.encodeMemberScores(members)
+	if err != nil {
+		return 0, err
+	}
+
+	metadata, hasMetadata, err := l.encodeMemberMetadata(members)
+	if err != nil {
+		return 0, err
+	}
+
+	defer l.setTTL(ctx)
+	if policy == UpdateReplace && !l.useTieBreak() && !hasMetadata {
+		listZ := make([]*redis.Z, 0, len(members))
+		for idx := range members {
+			listZ = append(listZ, &redis.Z{
+				Score:  values[idx],
+				Member: memberIDs[idx],
+			})
+		}
+
+		changed, err := l.redisClient.ZAddCh(ctx, l.rankSet, listZ...).Result()
+		return int(changed), err
+	}
+
+	scale, time := l.tieBreakArgs()
+	args := make([]interface{}, 0, len(members)*3+4)
+	args = append(args, string(policy), scale, time, l.maxScore())
+	for idx := range members {
+		args = append(args, memberIDs[idx], values[idx], metadata[idx])
+	}
+
+	changed, err := l.updateMembersScript.Run(ctx, l.redisClient, l.keys(), args...).Int()
+	return changed, parseScriptError(err)
+}
+
+func (l *TypedRedisLeaderboard[ID, S]) addMembersSameRank(ctx context.Context, members []TypedMember[ID, S], policy UpdatePolicy) (int, error) {
+	memberIDs, err := l.encodeMemberIDs(members)
+	if err != nil {
+		return 0, err
+	}
+
+	values, err := l.encodeMemberScores(members)
+	if err != nil {
+		return 0, err
+	}
+
+	metadata, _, err := l.encodeMemberMetadata(members)
+	if err != nil {
+		return 0, err
+	}
+
+	defer l.setTTL(ctx)
+	args := make([]interface{}, 0, len(members)*3+2)
+	args = append(args, string(policy), l.maxScore())
+	for idx := range members {
+		args = append(args, memberIDs[idx], values[idx], metadata[idx])
+	}
+
+	changed, err := l.addMembersScript.Run(ctx, l.redisClient, l.keys(), args...).Int()
+	return changed, parseScriptError(err)
+}
+
+// AddMembers add a list of members with their score to leaderboard in one call.
+// It works the same as AddMember for every member, field Rank of members is ignored.
+// Metadata of members is stored like AddMemberWithMetadata if it is not nil, in the same script as their scores.
+func (l *TypedRedisLeaderboard[ID, S]) AddMembers(ctx context.Context, members []TypedMember[ID, S]) error {
+	if len(members) == 0 {
+		return nil
+	}
+
+	addMembers := l.addMembers
+	if l.allowSameRank() {
+		addMembers = l.addMembersSameRank
+	}
+
+	_, err := addMembers(ctx, members, l.opts.updatePolicy())
+	return err
+}
+
+func (l *TypedRedisLeaderboard[ID, S]) incrementScore(ctx context.Context, id ID, delta S) (*TypedMember[ID, S], error) {
+	memberID, err := l.codec.EncodeID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := l.encodeScore(delta)
+	if err != nil {
+		return nil, err
+	}
+
+	defer l.setTTL(ctx)
+	scale, time := l.tieBreakArgs()
+	scoreRankTmp, err := l.incrementScoreOrdinalScript.Run(
+		ctx,
+		l.redisClient,
+		l.keys(),
+		memberID,
+		value,
+		scale,
+		time,
+		l.maxScore(),
+	).Result()
+	if err != nil {
+		return nil, parseScriptError(err)
+	}
+
+	return l.parseScoreRank(id, scoreRankTmp.([]interface{}))
+}
+
+func (l *TypedRedisLeaderboard[ID, S]) incrementScoreSameRank(ctx context.Context, id ID, delta S) (*TypedMember[ID, S], error) {
+	memberID, err := l.codec.EncodeID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := l.encodeScore(delta)
+	if err != nil {
+		return nil, err
+	}
+
+	defer l.setTTL(ctx)
+	scoreRankTmp, err := l.incrementScoreScript.Run(
+		ctx,
+		l.redisClient,
+		l.keys(),
+		memberID,
+		value,
+		string(l.opts.rankingScheme()),
+		l.maxScore(),
+	).Result()
+	if err != nil {
+		return nil, parseScriptError(err)
+	}
+
+	return l.parseScoreRank(id, scoreRankTmp.([]interface{}))
+}
+
+// IncrementScore increase score of a member by delta atomically, a negative delta will decrease it.
+// If member was not in leaderboard, it will be added with score is delta.
+// It returns the member with new score and rank.
+func (l *TypedRedisLeaderboard[ID, S]) IncrementScore(ctx context.Context, id ID, delta S) (*TypedMember[ID, S], error) {
+	if l.allowSameRank() {
+		return l.incrementScoreSameRank(ctx, id, delta)
+	}
+
+	return l.incrementScore(ctx, id, delta)
+}
+
+func (l *TypedRedisLeaderboard[ID, S]) removeMember(ctx context.Context, memberIDs ...interface{}) error {
+	fields := make([]string, 0, len(memberIDs))
+	for _, memberID := range memberIDs {
+		fields = append(fields, memberID.(string))
+	}
+
+	pipeline := l.redisClient.TxPipeline()
+	pipeline.ZRem(ctx, l.rankSet, memberIDs...)
+	pipeline.HDel(ctx, l.metadataHash, fields...)
+
+	_, err := pipeline.Exec(ctx)
+	return err
+}
+
+func (l *TypedRedisLeaderboard[ID, S]) removeMemberSameRank(ctx context.Context, memberIDs ...interface{}) error {
+	_, err := l.removeMemberScript.Run(ctx, l.redisClient, l.keys(), memberIDs...).Result()
+	return err
+}
+
+// RemoveMember remove members and their metadata from leaderboard by their ids, ids which are not in leaderboard will be ignored.
+func (l *TypedRedisLeaderboard[ID, S]) RemoveMember(ctx context.Context, ids ...ID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	memberIDs, err := l.encodeIDs(ids)
+	if err != nil {
+		return err
+	}
+
+	if l.allowSameRank() {
+		return l.removeMemberSameRank(ctx, memberIDs...)
+	}
+
+	return l.removeMember(ctx, memberIDs...)
+}
+
+func (l *TypedRedisLeaderboard[ID, S]) listMember(ctx context.Context, offset, limit int, order Order) ([]*TypedMember[ID, S], Cursor, error) {
+	pipeline := l.redisClient.Pipeline()
+	cmd := pipeline.ZRevRangeWithScores
 	if order == OrderAsc {
-		cmd = l.redisClient.ZRangeWithScores
+		cmd = pipeline.ZRangeWithScores
 	}
-	listMemberRedis, err := cmd(
+	listMemberCmd := cmd(
 		ctx,
-		generateRankSetName(l.name),
+		l.rankSet,
 		int64(offset),
 		int64(offset+limit-1),
-	).Result()
+	)
+	totalCmd := pipeline.ZCard(ctx, l.rankSet)
 
+	if _, err := pipeline.Exec(ctx); err != nil {
+		return nil, Cursor{}, err
+	}
+
+	listMember, err := l.rankMembers(ctx, listMemberCmd.Val())
 	if err != nil {
 		return nil, Cursor{}, err
 	}
 
+	return listMember, Cursor{
+		Begin: offset,
+		End:   offset + len(listMember),
+		Total: int(totalCmd.Val()),
+	}, nil
+}
+
+// rankMembers get rank of members listed from rank set in one pipeline.
+func (l *TypedRedisLeaderboard[ID, S]) rankMembers(ctx context.Context, listMemberRedis []redis.Z) ([]*TypedMember[ID, S], error) {
 	pipeline := l.redisClient.Pipeline()
 
 	uniqueScores := make(map[interface{}]*redis.IntCmd)
@@ -154,216 +643,618 @@ func (l *RedisLeaderboard) listMember(ctx context.Context, offset, limit int, or
 
 		rankCmd := pipeline.ZRevRank(
 			ctx,
-			generateRankSetName(l.name),
-			fmt.Sprintf("%v", member.Member),
+			l.rankSet,
+			member.Member.(string),
 		)
 		uniqueScores[member.Member] = rankCmd
 	}
 
 	if _, err := pipeline.Exec(ctx); err != nil {
-		return nil, Cursor{}, err
+		return nil, err
 	}
 
-	listMember := make([]*Member, 0, len(listMemberRedis))
+	listMember := make([]*TypedMember[ID, S], 0, len(listMemberRedis))
 	for _, member := range listMemberRedis {
-		mem := &Member{
-			ID:    member.Member,
-			Score: int(member.Score),
+		id, err := l.codec.DecodeID(member.Member.(string))
+		if err != nil {
+			return nil, err
+		}
+
+		score, err := l.decodeScore(member.Score)
+		if err != nil {
+			return nil, err
+		}
+
+		mem := &TypedMember[ID, S]{
+			ID:    id,
+			Score: score,
 			Rank:  int(uniqueScores[member.Member].Val()) + 1,
 		}
 		listMember = append(listMember, mem)
 	}
 
+	return listMember, nil
+}
+
+func (l *TypedRedisLeaderboard[ID, S]) listMemberSameRank(ctx context.Context, offset, limit int, order Order) ([]*TypedMember[ID, S], Cursor, error) {
+	listMemberRankTmp, err := l.listMemberScript.Run(ctx, l.redisClient, l.keys(), offset, limit, string(order), string(l.opts.rankingScheme())).Result()
+	if err != nil {
+		return nil, Cursor{}, err
+	}
+
+	listMember, total, err := l.parseListMemberWithRank(listMemberRankTmp.([]interface{}))
+	if err != nil {
+		return nil, Cursor{}, err
+	}
+
 	return listMember, Cursor{
 		Begin: offset,
 		End:   offset + len(listMember),
+		Total: total,
 	}, nil
 }
 
-func (l *RedisLeaderboard) listMemberSameRank(ctx context.Context, offset, limit int, order Order) ([]*Member, Cursor, error) {
-	listMemberRankTmp, err := l.listMemberScript.Run(ctx, l.redisClient, []string{l.name}, offset, limit, string(order)).Result()
+// List get list member with offset, limit and order in leaderboard
+func (l *TypedRedisLeaderboard[ID, S]) List(ctx context.Context, offset, limit int, order Order) ([]*TypedMember[ID, S], Cursor, error) {
+	list := l.listMember
+	if l.allowSameRank() {
+		list = l.listMemberSameRank
+	}
+
+	listMember, cursor, err := list(ctx, offset, limit, order)
 	if err != nil {
 		return nil, Cursor{}, err
 	}
 
-	listMemberRank := listMemberRankTmp.([]interface{})
-	listMember := make([]*Member, len(listMemberRank)/3)
-	for idx, val := range listMemberRank {
-		if idx%3 == 1 {
-			listMember[idx/3].Score = interfaceToInt(val)
-		} else if idx%3 == 2 {
-			listMember[idx/3].Rank = interfaceToInt(val)
-		} else {
-			listMember[idx/3] = &Member{
-				ID: val,
-			}
-		}
+	return listMember, cursor, l.attachMetadata(ctx, listMember...)
+}
+
+func (l *TypedRedisLeaderboard[ID, S]) listMemberByScore(ctx context.Context, min, max ScoreBound, offset, limit int, order Order) ([]*TypedMember[ID, S], Cursor, error) {
+	minRange, maxRange, err := l.scoreRange(min, max)
+	if err != nil {
+		return nil, Cursor{}, err
+	}
+
+	pipeline := l.redisClient.Pipeline()
+	cmd := pipeline.ZRevRangeByScoreWithScores
+	if order == OrderAsc {
+		cmd = pipeline.ZRangeByScoreWithScores
+	}
+	listMemberCmd := cmd(
+		ctx,
+		l.rankSet,
+		&redis.ZRangeBy{
+			Min:    minRange,
+			Max:    maxRange,
+			Offset: int64(offset),
+			Count:  int64(limit),
+		},
+	)
+	totalCmd := pipeline.ZCount(ctx, l.rankSet, minRange, maxRange)
+
+	if _, err := pipeline.Exec(ctx); err != nil {
+		return nil, Cursor{}, err
+	}
+
+	listMember, err := l.rankMembers(ctx, listMemberCmd.Val())
+	if err != nil {
+		return nil, Cursor{}, err
+	}
+
+	return listMember, Cursor{
+		Begin: offset,
+		End:   offset + len(listMember),
+		Total: int(totalCmd.Val()),
+	}, nil
+}
+
+func (l *TypedRedisLeaderboard[ID, S]) listMemberByScoreSameRank(ctx context.Context, min, max ScoreBound, offset, limit int, order Order) ([]*TypedMember[ID, S], Cursor, error) {
+	listMemberRankTmp, err := l.listMemberByScoreScript.Run(
+		ctx,
+		l.redisClient,
+		l.keys(),
+		string(min),
+		string(max),
+		offset,
+		limit,
+		string(order),
+		string(l.opts.rankingScheme()),
+	).Result()
+	if err != nil {
+		return nil, Cursor{}, err
+	}
+
+	listMember, total, err := l.parseListMemberWithRank(listMemberRankTmp.([]interface{}))
+	if err != nil {
+		return nil, Cursor{}, err
 	}
+
 	return listMember, Cursor{
 		Begin: offset,
 		End:   offset + len(listMember),
+		Total: total,
 	}, nil
 }
 
-// List get list member with offset, limit and order in leaderboard
-func (l *RedisLeaderboard) List(ctx context.Context, offset, limit int, order Order) ([]*Member, Cursor, error) {
-	if l.opts.AllowSameRank {
-		return l.listMemberSameRank(ctx, offset, limit, order)
+// ListByScore get list member whose score is in range [min, max] with offset, limit and order in leaderboard.
+// Offset and cursor are counted from the first member in the range.
+func (l *TypedRedisLeaderboard[ID, S]) ListByScore(ctx context.Context, min, max ScoreBound, offset, limit int, order Order) ([]*TypedMember[ID, S], Cursor, error) {
+	list := l.listMemberByScore
+	if l.allowSameRank() {
+		list = l.listMemberByScoreSameRank
+	}
+
+	listMember, cursor, err := list(ctx, min, max, offset, limit, order)
+	if err != nil {
+		return nil, Cursor{}, err
+	}
+
+	return listMember, cursor, l.attachMetadata(ctx, listMember...)
+}
+
+func (l *TypedRedisLeaderboard[ID, S]) getAround(ctx context.Context, id ID, limit int, order Order) ([]*TypedMember[ID, S], Cursor, error) {
+	memberID, err := l.codec.EncodeID(id)
+	if err != nil {
+		return nil, Cursor{}, err
+	}
+
+	rankCmd := l.redisClient.ZRevRank
+	if order == OrderAsc {
+		rankCmd = l.redisClient.ZRank
+	}
+	rank, err := rankCmd(
+		ctx,
+		l.rankSet,
+		memberID,
+	).Result()
+
+	if err != nil {
+		if err == redis.Nil {
+			return nil, Cursor{}, ErrMemberNotFound
+		}
+		return nil, Cursor{}, err
+	}
+
+	total, err := l.redisClient.ZCount(
+		ctx,
+		l.rankSet,
+		"-inf",
+		"+inf",
+	).Result()
+
+	if err != nil {
+		return nil, Cursor{}, err
+	}
+
+	start := cursorAround(
+		int(rank),
+		limit,
+		int(total),
+	)
+
+	return l.listMember(ctx, start, limit, order)
+}
+
+func (l *TypedRedisLeaderboard[ID, S]) getAroundSameRank(ctx context.Context, id ID, limit int, order Order) ([]*TypedMember[ID, S], Cursor, error) {
+	memberID, err := l.codec.EncodeID(id)
+	if err != nil {
+		return nil, Cursor{}, err
+	}
+
+	pipeline := l.redisClient.Pipeline()
+	listMemberRankCmd := l.getAroundScript.Eval(ctx, pipeline, l.keys(), memberID, limit, string(order), string(l.opts.rankingScheme()))
+
+	rankCmd := pipeline.ZRevRank
+	if order == OrderAsc {
+		rankCmd = pipeline.ZRank
+	}
+	getRankCmd := rankCmd(
+		ctx,
+		l.memberScoreSet,
+		memberID,
+	)
+
+	// script fails when member is not in leaderboard, so rank is checked first
+	if _, err := pipeline.Exec(ctx); err != nil {
+		if getRankCmd.Err() == redis.Nil {
+			return nil, Cursor{}, ErrMemberNotFound
+		}
+		return nil, Cursor{}, err
+	}
+
+	listMemberRankTmp, _ := listMemberRankCmd.Result()
+	rank, _ := getRankCmd.Result()
+	offset := 0
+
+	listMemberRank := listMemberRankTmp.([]interface{})
+	listMember, total, err := l.parseListMemberWithRank(listMemberRank)
+	if err != nil {
+		return nil, Cursor{}, err
+	}
+
+	// ids are compared before they are decoded, AnyCodec decodes an id like 42 to "42"
+	for idx := range listMember {
+		if listMemberRank[1+idx*3].(string) == memberID {
+			offset = int(rank) - idx
+		}
+	}
+
+	cursor := Cursor{Total: total}
+	if len(listMember) > 0 {
+		cursor.Begin = offset
+		cursor.End = cursor.Begin + len(listMember)
+	}
+
+	return listMember, cursor, nil
+}
+
+// GetAround get list member around another member with limit and order.
+// It returns ErrMemberNotFound if member is not in leaderboard.
+func (l *TypedRedisLeaderboard[ID, S]) GetAround(ctx context.Context, id ID, limit int, order Order) ([]*TypedMember[ID, S], Cursor, error) {
+	getAround := l.getAround
+	if l.allowSameRank() {
+		getAround = l.getAroundSameRank
+	}
+
+	listMember, cursor, err := getAround(ctx, id, limit, order)
+	if err != nil {
+		return nil, Cursor{}, err
+	}
+
+	return listMember, cursor, l.attachMetadata(ctx, listMember...)
+}
+
+func (l *TypedRedisLeaderboard[ID, S]) getRank(ctx context.Context, id ID) (int, error) {
+	memberID, err := l.codec.EncodeID(id)
+	if err != nil {
+		return 0, err
+	}
+
+	rank, err := l.redisClient.ZRevRank(
+		ctx,
+		l.rankSet,
+		memberID,
+	).Result()
+
+	if err != nil {
+		if err == redis.Nil {
+			return 0, ErrMemberNotFound
+		}
+		return 0, err
+	}
+
+	return int(rank) + 1, nil
+}
+
+func (l *TypedRedisLeaderboard[ID, S]) getRankSameRank(ctx context.Context, id ID) (int, error) {
+	memberID, err := l.codec.EncodeID(id)
+	if err != nil {
+		return 0, err
+	}
+
+	rankData, err := l.getRankScript.Run(ctx, l.redisClient, l.keys(), memberID, string(l.opts.rankingScheme())).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return 0, ErrMemberNotFound
+		}
+		return 0, err
+	}
+
+	rank := rankData.(int64)
+	return int(rank), nil
+}
+
+// GetRank get rank of a member.
+// It returns ErrMemberNotFound if member is not in leaderboard.
+func (l *TypedRedisLeaderboard[ID, S]) GetRank(ctx context.Context, id ID) (int, error) {
+	if l.allowSameRank() {
+		return l.getRankSameRank(ctx, id)
+	}
+
+	return l.getRank(ctx, id)
+}
+
+func (l *TypedRedisLeaderboard[ID, S]) getPercentile(ctx context.Context, id ID) (float64, error) {
+	memberID, err := l.codec.EncodeID(id)
+	if err != nil {
+		return 0, err
+	}
+
+	pipeline := l.redisClient.TxPipeline()
+	rankCmd := pipeline.ZRevRank(ctx, l.rankSet, memberID)
+	totalCmd := pipeline.ZCard(ctx, l.rankSet)
+
+	if _, err := pipeline.Exec(ctx); err != nil {
+		if err == redis.Nil {
+			return 0, ErrMemberNotFound
+		}
+		return 0, err
+	}
+
+	return percentile(int(rankCmd.Val())+1, int(totalCmd.Val())), nil
+}
+
+func (l *TypedRedisLeaderboard[ID, S]) getPercentileSameRank(ctx context.Context, id ID) (float64, error) {
+	memberID, err := l.codec.EncodeID(id)
+	if err != nil {
+		return 0, err
+	}
+
+	rankTotalTmp, err := l.getPercentileScript.Run(ctx, l.redisClient, l.keys(), memberID, string(l.opts.rankingScheme())).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return 0, ErrMemberNotFound
+		}
+		return 0, err
+	}
+
+	rankTotal := rankTotalTmp.([]interface{})
+	return percentile(int(rankTotal[0].(int64)), int(rankTotal[1].(int64))), nil
+}
+
+// GetPercentile get percentile of a member in leaderboard, it is in range (0, 100] and smaller is better,
+// for example a member with percentile 3 is in top 3% of leaderboard.
+// With RankDense it is the rank of member over number of distinct scores, otherwise it is the rank over number of members.
+// Members with the same rank have the same percentile.
+// It returns ErrMemberNotFound if member is not in leaderboard.
+func (l *TypedRedisLeaderboard[ID, S]) GetPercentile(ctx context.Context, id ID) (float64, error) {
+	if l.allowSameRank() {
+		return l.getPercentileSameRank(ctx, id)
+	}
+
+	return l.getPercentile(ctx, id)
+}
+
+func (l *TypedRedisLeaderboard[ID, S]) getMember(ctx context.Context, id ID) (*TypedMember[ID, S], error) {
+	memberID, err := l.codec.EncodeID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	pipeline := l.redisClient.TxPipeline()
+	scoreCmd := pipeline.ZScore(ctx, l.rankSet, memberID)
+	rankCmd := pipeline.ZRevRank(ctx, l.rankSet, memberID)
+
+	if _, err := pipeline.Exec(ctx); err != nil {
+		if err == redis.Nil {
+			return nil, ErrMemberNotFound
+		}
+		return nil, err
+	}
+
+	score, err := l.decodeScore(scoreCmd.Val())
+	if err != nil {
+		return nil, err
 	}
 
-	return l.listMember(ctx, offset, limit, order)
+	return &TypedMember[ID, S]{
+		ID:    id,
+		Score: score,
+		Rank:  int(rankCmd.Val()) + 1,
+	}, nil
 }
 
-func (l *RedisLeaderboard) getAround(ctx context.Context, id interface{}, limit int, order Order) ([]*Member, Cursor, error) {
-	rankCmd := l.redisClient.ZRevRank
-	if order == OrderAsc {
-		rankCmd = l.redisClient.ZRank
+func (l *TypedRedisLeaderboard[ID, S]) getMemberSameRank(ctx context.Context, id ID) (*TypedMember[ID, S], error) {
+	memberID, err := l.codec.EncodeID(id)
+	if err != nil {
+		return nil, err
 	}
-	rank, err := rankCmd(
-		ctx,
-		generateRankSetName(l.name),
-		fmt.Sprintf("%v", id),
-	).Result()
 
+	scoreRankTmp, err := l.getMemberScript.Run(ctx, l.redisClient, l.keys(), memberID, string(l.opts.rankingScheme())).Result()
 	if err != nil {
-		return nil, Cursor{}, err
+		if err == redis.Nil {
+			return nil, ErrMemberNotFound
+		}
+		return nil, err
 	}
 
-	total, err := l.redisClient.ZCount(
-		ctx,
-		generateRankSetName(l.name),
-		"-inf",
-		"+inf",
-	).Result()
+	return l.parseScoreRank(id, scoreRankTmp.([]interface{}))
+}
 
-	if err != nil {
-		return nil, Cursor{}, err
+// GetMember get score and rank of a member in one call.
+// It returns ErrMemberNotFound if member is not in leaderboard.
+func (l *TypedRedisLeaderboard[ID, S]) GetMember(ctx context.Context, id ID) (*TypedMember[ID, S], error) {
+	getMember := l.getMember
+	if l.allowSameRank() {
+		getMember = l.getMemberSameRank
 	}
 
-	start := cursorAround(
-		int(rank),
-		limit,
-		int(total),
-	)
+	member, err := getMember(ctx, id)
+	if err != nil {
+		return nil, err
+	}
 
-	return l.List(ctx, start, limit, order)
+	return member, l.attachMetadata(ctx, member)
 }
 
-func (l *RedisLeaderboard) getAroundSameRank(ctx context.Context, id interface{}, limit int, order Order) ([]*Member, Cursor, error) {
+func (l *TypedRedisLeaderboard[ID, S]) getMembers(ctx context.Context, ids []ID, memberIDs []interface{}) ([]*TypedMember[ID, S], error) {
 	pipeline := l.redisClient.Pipeline()
-	listMemberRankCmd := pipeline.EvalSha(ctx, l.getAroundScript.Hash(), []string{l.name}, id, limit, string(order))
-
-	rankCmd := pipeline.ZRevRank
-	if order == OrderAsc {
-		rankCmd = pipeline.ZRank
+	scoreCmds := make([]*redis.FloatCmd, 0, len(ids))
+	rankCmds := make([]*redis.IntCmd, 0, len(ids))
+	for _, memberID := range memberIDs {
+		scoreCmds = append(scoreCmds, pipeline.ZScore(ctx, l.rankSet, memberID.(string)))
+		rankCmds = append(rankCmds, pipeline.ZRevRank(ctx, l.rankSet, memberID.(string)))
 	}
-	getRankCmd := rankCmd(
-		ctx,
-		generateMemScoreSetName(l.name),
-		fmt.Sprintf("%v", id),
-	)
 
-	if _, err := pipeline.Exec(ctx); err != nil {
-		return nil, Cursor{}, err
+	if _, err := pipeline.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, err
 	}
 
-	listMemberRankTmp, _ := listMemberRankCmd.Result()
-	rank, _ := getRankCmd.Result()
-	offset := 0
+	listMember := make([]*TypedMember[ID, S], len(ids))
+	for idx, id := range ids {
+		if scoreCmds[idx].Err() == redis.Nil || rankCmds[idx].Err() == redis.Nil {
+			continue
+		}
 
-	listMemberRank := listMemberRankTmp.([]interface{})
-	listMember := make([]*Member, len(listMemberRank)/3)
-	for idx, val := range listMemberRank {
-		if idx%3 == 1 {
-			listMember[idx/3].Score = interfaceToInt(val)
-		} else if idx%3 == 2 {
-			listMember[idx/3].Rank = interfaceToInt(val)
-		} else {
-			listMember[idx/3] = &Member{
-				ID: val,
-			}
-			if val == id {
-				offset = int(rank) - idx/3
-			}
+		score, err := l.decodeScore(scoreCmds[idx].Val())
+		if err != nil {
+			return nil, err
 		}
-	}
 
-	cursor := Cursor{}
-	if len(listMember) > 0 {
-		cursor.Begin = offset
-		cursor.End = cursor.Begin + len(listMember)
+		listMember[idx] = &TypedMember[ID, S]{
+			ID:    id,
+			Score: score,
+			Rank:  int(rankCmds[idx].Val()) + 1,
+		}
 	}
 
-	return listMember, cursor, nil
+	return listMember, nil
 }
 
-// GetAround get list member around another member with limit and order
-func (l *RedisLeaderboard) GetAround(ctx context.Context, id interface{}, limit int, order Order) ([]*Member, Cursor, error) {
-	if l.opts.AllowSameRank {
-		return l.getAroundSameRank(ctx, id, limit, order)
+func (l *TypedRedisLeaderboard[ID, S]) getMembersSameRank(ctx context.Context, ids []ID, memberIDs []interface{}) ([]*TypedMember[ID, S], error) {
+	args := make([]interface{}, 0, len(ids)+1)
+	args = append(args, string(l.opts.rankingScheme()))
+	args = append(args, memberIDs...)
+	listScoreRankTmp, err := l.getMembersScript.Run(ctx, l.redisClient, l.keys(), args...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	listScoreRank := listScoreRankTmp.([]interface{})
+	listMember := make([]*TypedMember[ID, S], len(ids))
+	for idx, id := range ids {
+		if listScoreRank[idx*2] == nil {
+			continue
+		}
+
+		member, err := l.parseScoreRank(id, listScoreRank[idx*2:idx*2+2])
+		if err != nil {
+			return nil, err
+		}
+		listMember[idx] = member
 	}
 
-	return l.getAround(ctx, id, limit, order)
+	return listMember, nil
 }
 
-func (l *RedisLeaderboard) getRank(ctx context.Context, id interface{}) (int, error) {
-	rank, err := l.redisClient.ZRevRank(
-		ctx,
-		generateRankSetName(l.name),
-		fmt.Sprintf("%v", id),
-	).Result()
+// GetMembers get score and rank of a list of members in one call.
+// Members are returned in the same order as ids, a member is nil if its id is not in leaderboard.
+func (l *TypedRedisLeaderboard[ID, S]) GetMembers(ctx context.Context, ids []ID) ([]*TypedMember[ID, S], error) {
+	if len(ids) == 0 {
+		return []*TypedMember[ID, S]{}, nil
+	}
 
+	memberIDs, err := l.encodeIDs(ids)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
-	return int(rank) + 1, nil
-}
+	getMembers := l.getMembers
+	if l.allowSameRank() {
+		getMembers = l.getMembersSameRank
+	}
 
-func (l *RedisLeaderboard) getRankSameRank(ctx context.Context, id interface{}) (int, error) {
-	rankData, err := l.getRankScript.Run(ctx, l.redisClient, []string{l.name}, id).Result()
+	listMember, err := getMembers(ctx, ids, memberIDs)
 	if err != nil {
-		return 0, nil
+		return nil, err
 	}
 
-	rank := rankData.(int64)
-	return int(rank), nil
+	return listMember, l.attachMetadata(ctx, listMember...)
 }
 
-// GetRank get rank of a member
-func (l *RedisLeaderboard) GetRank(ctx context.Context, id interface{}) (int, error) {
-	if l.opts.AllowSameRank {
-		return l.getRankSameRank(ctx, id)
+// memberSet is the name of the set storing members with their score.
+func (l *TypedRedisLeaderboard[ID, S]) memberSet() string {
+	if l.allowSameRank() {
+		return l.memberScoreSet
 	}
 
-	return l.getRank(ctx, id)
+	return l.rankSet
+}
+
+// Count get number of members in leaderboard
+func (l *TypedRedisLeaderboard[ID, S]) Count(ctx context.Context) (int, error) {
+	total, err := l.redisClient.ZCard(ctx, l.memberSet()).Result()
+	return int(total), err
+}
+
+// CountByScore get number of members whose score is in range [min, max]
+func (l *TypedRedisLeaderboard[ID, S]) CountByScore(ctx context.Context, min, max ScoreBound) (int, error) {
+	minRange, maxRange, err := l.scoreRange(min, max)
+	if err != nil {
+		return 0, err
+	}
+
+	total, err := l.redisClient.ZCount(ctx, l.memberSet(), minRange, maxRange).Result()
+	return int(total), err
 }
 
 // Clean clear all data of leaderboard in redis
-func (l *RedisLeaderboard) Clean(ctx context.Context) error {
+func (l *TypedRedisLeaderboard[ID, S]) Clean(ctx context.Context) error {
 	pipeline := l.redisClient.Pipeline()
-	pipeline.Del(ctx, generateRankSetName(l.name))
-	pipeline.Del(ctx, generateMemScoreSetName(l.name))
+	pipeline.Del(ctx, l.rankSet)
+	pipeline.Del(ctx, l.memberScoreSet)
+	pipeline.Del(ctx, l.metadataHash)
 
 	_, err := pipeline.Exec(ctx)
 	return err
 }
 
-func initAddMemberScript() string {
+func initGetRankFunctionScript() string {
+	return `
+local function get_rank(scheme, member_score_set, rank_set, score)
+	if scheme == "standard_competition" then
+		return redis.call("ZCOUNT", member_score_set, "(" .. score, "+inf") + 1
+	end
+
+	if scheme == "modified_competition" then
+		return redis.call("ZCOUNT", member_score_set, score, "+inf")
+	end
+
+	return redis.call("ZREVRANK", rank_set, score) + 1
+end
+`
+}
+
+func initApplyPolicyFunctionScript() string {
 	return `
-local key = KEYS[1]
+local function apply_policy(policy, old_score, new_score)
+	if not old_score then
+		return new_score
+	end
+
+	if policy == "sum" then
+		new_score = old_score + new_score
+	elseif policy == "keep_highest" then
+		new_score = math.max(old_score, new_score)
+	elseif policy == "keep_lowest" then
+		new_score = math.min(old_score, new_score)
+	end
+
+	if new_score == old_score then
+		return nil
+	end
+
+	return new_score
+end
+`
+}
+
+func initAddMemberScript() string {
+	return initApplyPolicyFunctionScript() + `
 local member_id = ARGV[1]
-local new_score = ARGV[2]
+local score = tonumber(ARGV[2])
+local policy = ARGV[3]
+local max = tonumber(ARGV[4])
 
-local member_score_set = "goleaderboard:" .. key .. ":member_score_set"
-local rank_set = "goleaderboard:" .. key .. ":rank_set"
+local rank_set = KEYS[1]
+local member_score_set = KEYS[2]
 
 local old_score = redis.call("ZSCORE", member_score_set, member_id)
-	
-redis.call("ZADD", rank_set, new_score, new_score)
+local new_score = apply_policy(policy, tonumber(old_score), score)
+if not new_score then
+	return 0
+end
+
+if max > 0 and math.abs(new_score) > max then
+	return redis.error_reply("goleaderboard: score is out of range")
+end
+
 redis.call("ZADD", member_score_set, new_score, member_id)
+-- distinct scores are stored in the format of Redis to be found by scores of members
+new_score = redis.call("ZSCORE", member_score_set, member_id)
+redis.call("ZADD", rank_set, new_score, new_score)
 
-if old_score ~= nil then
+if not old_score then
 	return 1
 end
 
@@ -376,15 +1267,221 @@ return 1
 `
 }
 
-func initGetListMemberWithRankScript() string {
+func initAddMembersScript() string {
+	return initApplyPolicyFunctionScript() + initSetMetadataFunctionScript() + `
+local policy = ARGV[1]
+local max = tonumber(ARGV[2])
+
+local rank_set = KEYS[1]
+local member_score_set = KEYS[2]
+local metadata = KEYS[3]
+
+-- members are given as id, score and metadata
+-- check all scores before writing, so members are added all or none
+local new_scores = {}
+local changed_ids = {}
+for idx = 3, #ARGV, 3 do
+	local member_id = ARGV[idx]
+
+	local old_score = new_scores[member_id]
+	if not old_score then
+		old_score = tonumber(redis.call("ZSCORE", member_score_set, member_id))
+	end
+
+	local new_score = apply_policy(policy, old_score, tonumber(ARGV[idx + 1]))
+	if new_score then
+		if max > 0 and math.abs(new_score) > max then
+			return redis.error_reply("goleaderboard: score is out of range")
+		end
+
+		if not new_scores[member_id] then
+			table.insert(changed_ids, member_id)
+		end
+		new_scores[member_id] = new_score
+	end
+end
+
+local old_scores = {}
+for _, member_id in ipairs(changed_ids) do
+	local old_score = redis.call("ZSCORE", member_score_set, member_id)
+	if old_score then
+		old_scores[old_score] = true
+	end
+
+	redis.call("ZADD", member_score_set, new_scores[member_id], member_id)
+	local new_score = redis.call("ZSCORE", member_score_set, member_id)
+	redis.call("ZADD", rank_set, new_score, new_score)
+end
+
+for old_score, _ in pairs(old_scores) do
+	local count_member_in_old_score = redis.call("ZCOUNT", member_score_set, old_score, old_score)
+	if count_member_in_old_score == 0 then
+		redis.call("ZREM", rank_set, old_score)
+	end
+end
+
+for idx = 3, #ARGV, 3 do
+	set_metadata(metadata, ARGV[idx], ARGV[idx + 2])
+end
+
+return #changed_ids
+`
+}
+
+func initUpdateMembersScript() string {
+	return initApplyPolicyFunctionScript() + initSetMetadataFunctionScript() + `
+local policy = ARGV[1]
+local scale = tonumber(ARGV[2])
+local time = tonumber(ARGV[3])
+local max = tonumber(ARGV[4])
+
+local rank_set = KEYS[1]
+local metadata = KEYS[3]
+
+-- members are given as id, score and metadata
+-- score of member is stored as score * scale + time, time is 0 and scale is 1 without tie break
+local new_scores = {}
+local changed_ids = {}
+for idx = 5, #ARGV, 3 do
+	local member_id = ARGV[idx]
+
+	local old_score = new_scores[member_id]
+	if not old_score then
+		old_score = tonumber(redis.call("ZSCORE", rank_set, member_id))
+		if old_score and scale ~= 1 then
+			old_score = math.floor(old_score / scale)
+		end
+	end
+
+	local new_score = apply_policy(policy, old_score, tonumber(ARGV[idx + 1]))
+	if new_score then
+		if max > 0 and math.abs(new_score) > max then
+			return redis.error_reply("goleaderboard: score is out of range")
+		end
+
+		if not new_scores[member_id] then
+			table.insert(changed_ids, member_id)
+		end
+		new_scores[member_id] = new_score
+	end
+end
+
+for _, member_id in ipairs(changed_ids) do
+	redis.call("ZADD", rank_set, new_scores[member_id] * scale + time, member_id)
+end
+
+for idx = 5, #ARGV, 3 do
+	set_metadata(metadata, ARGV[idx], ARGV[idx + 2])
+end
+
+return #changed_ids
+`
+}
+
+func initIncrementScoreScript() string {
+	return initGetRankFunctionScript() + `
+local member_id = ARGV[1]
+local delta = ARGV[2]
+local scheme = ARGV[3]
+local max = tonumber(ARGV[4])
+
+local rank_set = KEYS[1]
+local member_score_set = KEYS[2]
+
+local old_score = redis.call("ZSCORE", member_score_set, member_id)
+if max > 0 and math.abs((tonumber(old_score) or 0) + tonumber(delta)) > max then
+	return redis.error_reply("goleaderboard: score is out of range")
+end
+
+local new_score = redis.call("ZINCRBY", member_score_set, delta, member_id)
+
+redis.call("ZADD", rank_set, new_score, new_score)
+
+if old_score and old_score ~= new_score then
+	local count_member_in_old_score = redis.call("ZCOUNT", member_score_set, old_score, old_score)
+	if count_member_in_old_score == 0 then
+		redis.call("ZREM", rank_set, old_score)
+	end
+end
+
+local rank = get_rank(scheme, member_score_set, rank_set, new_score)
+
+return {new_score, tostring(rank)}
+`
+}
+
+func initIncrementScoreOrdinalScript() string {
+	return `
+local member_id = ARGV[1]
+local delta = tonumber(ARGV[2])
+local scale = tonumber(ARGV[3])
+local time = tonumber(ARGV[4])
+local max = tonumber(ARGV[5])
+
+local rank_set = KEYS[1]
+
+-- score of member is stored as score * scale + time, time is 0 and scale is 1 without tie break
+local old_score = tonumber(redis.call("ZSCORE", rank_set, member_id))
+local score = old_score or 0
+if old_score and scale ~= 1 then
+	score = math.floor(old_score / scale)
+end
+
+local new_score = score + delta
+if max > 0 and math.abs(new_score) > max then
+	return redis.error_reply("goleaderboard: score is out of range")
+end
+
+if scale == 1 then
+	redis.call("ZINCRBY", rank_set, ARGV[2], member_id)
+elseif not old_score or new_score ~= score then
+	redis.call("ZADD", rank_set, new_score * scale + time, member_id)
+end
+
+local rank = redis.call("ZREVRANK", rank_set, member_id)
+
+return {redis.call("ZSCORE", rank_set, member_id), rank + 1}
+`
+}
+
+func initRemoveMemberScript() string {
 	return `
-local key = KEYS[1]
+
+local rank_set = KEYS[1]
+local member_score_set = KEYS[2]
+local metadata = KEYS[3]
+
+-- metadata is deleted in chunks, unpack can not put too many ids on the stack of Lua
+for idx = 1, #ARGV, 1000 do
+	redis.call("HDEL", metadata, unpack(ARGV, idx, math.min(idx + 999, #ARGV)))
+end
+
+local removed = 0
+for _, member_id in ipairs(ARGV) do
+	local old_score = redis.call("ZSCORE", member_score_set, member_id)
+	if old_score then
+		removed = removed + redis.call("ZREM", member_score_set, member_id)
+
+		local count_member_in_old_score = redis.call("ZCOUNT", member_score_set, old_score, old_score)
+		if count_member_in_old_score == 0 then
+			redis.call("ZREM", rank_set, old_score)
+		end
+	end
+end
+
+return removed
+`
+}
+
+func initGetListMemberWithRankScript() string {
+	return initGetRankFunctionScript() + `
 local offset = ARGV[1]
 local limit = ARGV[2]
 local order = ARGV[3]
+local scheme = ARGV[4]
 
-local member_score_set = "goleaderboard:" .. key .. ":member_score_set"
-local rank_set = "goleaderboard:" .. key .. ":rank_set"
+local rank_set = KEYS[1]
+local member_score_set = KEYS[2]
 
 local listCmd = "ZREVRANGE"
 if order == "asc" then
@@ -393,14 +1490,48 @@ end
 
 local list_member_with_score = redis.call(listCmd, member_score_set, offset, offset + limit - 1, "WITHSCORES")
 
-local list_member_with_rank = {}
+local list_member_with_rank = {redis.call("ZCARD", member_score_set)}
 
 for idx,val in ipairs(list_member_with_score) do
 	table.insert(list_member_with_rank, val) 
 
 	if idx % 2 == 0 then 
-		local rank = redis.call("ZREVRANK", rank_set, val)
-		table.insert(list_member_with_rank, tostring(rank + 1))
+		local rank = get_rank(scheme, member_score_set, rank_set, val)
+		table.insert(list_member_with_rank, tostring(rank))
+	end
+end
+
+return list_member_with_rank
+`
+}
+
+func initGetListMemberByScoreWithRankScript() string {
+	return initGetRankFunctionScript() + `
+local min = ARGV[1]
+local max = ARGV[2]
+local offset = ARGV[3]
+local limit = ARGV[4]
+local order = ARGV[5]
+local scheme = ARGV[6]
+
+local rank_set = KEYS[1]
+local member_score_set = KEYS[2]
+
+local list_member_with_score = {}
+if order == "asc" then
+	list_member_with_score = redis.call("ZRANGEBYSCORE", member_score_set, min, max, "WITHSCORES", "LIMIT", offset, limit)
+else
+	list_member_with_score = redis.call("ZREVRANGEBYSCORE", member_score_set, max, min, "WITHSCORES", "LIMIT", offset, limit)
+end
+
+local list_member_with_rank = {redis.call("ZCOUNT", member_score_set, min, max)}
+
+for idx,val in ipairs(list_member_with_score) do
+	table.insert(list_member_with_rank, val)
+
+	if idx % 2 == 0 then
+		local rank = get_rank(scheme, member_score_set, rank_set, val)
+		table.insert(list_member_with_rank, tostring(rank))
 	end
 end
 
@@ -409,33 +1540,101 @@ return list_member_with_rank
 }
 
 func initGetRankScript() string {
-	return `
-local key = KEYS[1]
+	return initGetRankFunctionScript() + `
 local id = ARGV[1]
+local scheme = ARGV[2]
 
-local member_score_set = "goleaderboard:" .. key .. ":member_score_set"
-local rank_set = "goleaderboard:" .. key .. ":rank_set"
+local rank_set = KEYS[1]
+local member_score_set = KEYS[2]
 
 local score = redis.call("ZSCORE", member_score_set, id)
-local rank = redis.call("ZREVRANK", rank_set, tostring(score))
+if not score then
+	return false
+end
+
+return get_rank(scheme, member_score_set, rank_set, score)
+`
+}
+
+func initGetPercentileScript() string {
+	return initGetRankFunctionScript() + `
+local id = ARGV[1]
+local scheme = ARGV[2]
+
+local rank_set = KEYS[1]
+local member_score_set = KEYS[2]
+
+local score = redis.call("ZSCORE", member_score_set, id)
+if not score then
+	return false
+end
+
+local rank = get_rank(scheme, member_score_set, rank_set, score)
+
+local total = 0
+if scheme == "dense" then
+	total = redis.call("ZCARD", rank_set)
+else
+	total = redis.call("ZCARD", member_score_set)
+end
+
+return {rank, total}
+`
+}
 
-if not rank then
-	return redis.call("ZCOUNT", rank_set, "-inf", "+inf") + 1
+func initGetMemberScript() string {
+	return initGetRankFunctionScript() + `
+local id = ARGV[1]
+local scheme = ARGV[2]
+
+local rank_set = KEYS[1]
+local member_score_set = KEYS[2]
+
+local score = redis.call("ZSCORE", member_score_set, id)
+if not score then
+	return false
+end
+
+local rank = get_rank(scheme, member_score_set, rank_set, score)
+
+return {score, tostring(rank)}
+`
+}
+
+func initGetMembersScript() string {
+	return initGetRankFunctionScript() + `
+local scheme = ARGV[1]
+
+local rank_set = KEYS[1]
+local member_score_set = KEYS[2]
+
+local list_score_with_rank = {}
+
+for idx = 2, #ARGV do
+	local score = redis.call("ZSCORE", member_score_set, ARGV[idx])
+	if score then
+		local rank = get_rank(scheme, member_score_set, rank_set, score)
+		table.insert(list_score_with_rank, score)
+		table.insert(list_score_with_rank, tostring(rank))
+	else
+		table.insert(list_score_with_rank, false)
+		table.insert(list_score_with_rank, false)
+	end
 end
 
-return rank + 1
+return list_score_with_rank
 `
 }
 
 func initGetAroundScript() string {
-	return `
-local key = KEYS[1]
+	return initGetRankFunctionScript() + `
 local id = ARGV[1]
 local limit = ARGV[2]
 local order = ARGV[3]
+local scheme = ARGV[4]
 
-local member_score_set = "goleaderboard:" .. key .. ":member_score_set"
-local rank_set = "goleaderboard:" .. key .. ":rank_set"
+local rank_set = KEYS[1]
+local member_score_set = KEYS[2]
 
 local rankCmd = "ZREVRANK"
 if order == "asc" then
@@ -465,14 +1664,14 @@ end
 
 local list_member_with_score = redis.call(listCmd, member_score_set, offset, offset + limit - 1, "WITHSCORES")
 
-local list_member_with_rank = {}
+local list_member_with_rank = {total}
 
 for idx,val in ipairs(list_member_with_score) do
 	table.insert(list_member_with_rank, val) 
 
 	if idx % 2 == 0 then 
-		local rank = redis.call("ZREVRANK", rank_set, val)
-		table.insert(list_member_with_rank, tostring(rank + 1))
+		local rank = get_rank(scheme, member_score_set, rank_set, val)
+		table.insert(list_member_with_rank, tostring(rank))
 	end
 end
 
@@ -480,6 +1679,16 @@ return list_member_with_rank
 `
 }
 
+// hashTag get name with a hash tag, so all keys of a leaderboard are in the same slot of Redis Cluster.
+// A name which already has a hash tag is kept, so leaderboards can share a slot, for example "{kills}:solo" and "{kills}:duo".
+func hashTag(name string) string {
+	if start := strings.Index(name, "{"); start >= 0 && strings.Index(name[start+1:], "}") > 0 {
+		return name
+	}
+
+	return "{" + name + "}"
+}
+
 func generateRankSetName(name string) string {
 	return fmt.Sprintf("goleaderboard:%s:rank_set", name)
 }
@@ -488,10 +1697,73 @@ func generateMemScoreSetName(name string) string {
 	return fmt.Sprintf("goleaderboard:%s:member_score_set", name)
 }
 
-func interfaceToInt(val interface{}) int {
-	str := fmt.Sprintf("%s", val)
-	v, _ := strconv.ParseInt(str, 10, 64)
-	return int(v)
+func generateMetadataHashName(name string) string {
+	return fmt.Sprintf("goleaderboard:%s:metadata", name)
+}
+
+func generateSeasonSetName(name string) string {
+	return fmt.Sprintf("goleaderboard:%s:seasons", name)
+}
+
+// parseListMemberWithRank parse total and list of id, score and rank returned by scripts to members.
+func (l *TypedRedisLeaderboard[ID, S]) parseListMemberWithRank(totalWithListMemberRank []interface{}) ([]*TypedMember[ID, S], int, error) {
+	total := int(totalWithListMemberRank[0].(int64))
+	listMemberRank := totalWithListMemberRank[1:]
+	listMember := make([]*TypedMember[ID, S], len(listMemberRank)/3)
+	for idx := range listMember {
+		id, err := l.codec.DecodeID(listMemberRank[idx*3].(string))
+		if err != nil {
+			return nil, 0, err
+		}
+
+		member, err := l.parseScoreRank(id, listMemberRank[idx*3+1:idx*3+3])
+		if err != nil {
+			return nil, 0, err
+		}
+		listMember[idx] = member
+	}
+	return listMember, total, nil
+}
+
+// parseScoreRank parse score and rank of a member returned by scripts.
+func (l *TypedRedisLeaderboard[ID, S]) parseScoreRank(id ID, scoreRank []interface{}) (*TypedMember[ID, S], error) {
+	score, err := l.parseScore(scoreRank[0])
+	if err != nil {
+		return nil, err
+	}
+
+	rank, err := parseRank(scoreRank[1])
+	if err != nil {
+		return nil, err
+	}
+
+	return &TypedMember[ID, S]{
+		ID:    id,
+		Score: score,
+		Rank:  rank,
+	}, nil
+}
+
+// parseScriptError convert errors returned by scripts to errors of package.
+func parseScriptError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	for _, scriptErr := range []error{ErrScoreOutOfRange, ErrSeasonArchived} {
+		if strings.HasSuffix(err.Error(), scriptErr.Error()) {
+			return scriptErr
+		}
+	}
+
+	return err
+}
+
+func percentile(rank, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(rank) * 100 / float64(total)
 }
 
 func maxInt(nums ...int) int {
@@ -2,24 +2,37 @@ package goleaderboard
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/go-redis/redis/v8"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 var (
 	redisClient *redis.Client
 )
 
+// setup connect Redis at GOLEADERBOARD_REDIS_ADDR, default is localhost:6379.
+// Tests which need Redis are skipped if it is not available.
 func setup(t *testing.T) {
+	addr := os.Getenv("GOLEADERBOARD_REDIS_ADDR")
+	if addr == "" {
+		addr = "localhost:6379"
+	}
+
 	redisClient = redis.NewClient(&redis.Options{
-		Addr:     "localhost:6379",
+		Addr:     addr,
 		Password: "", // no password set
 		DB:       0,  // use default DB
 	})
 
 	if err := redisClient.Ping(context.Background()).Err(); err != nil {
-		t.Fatal("you must start redis server", err)
+		redisClient.Close()
+		t.Skip("redis is not available at", addr, err.Error())
 	}
 }
 
@@ -29,7 +42,7 @@ func teardown(t *testing.T) {
 	}
 }
 
-func clean(t *testing.T, ctx context.Context, leader Leaderboard) {
+func clean[ID comparable, S Score](t *testing.T, ctx context.Context, leader TypedLeaderboard[ID, S]) {
 	if err := leader.Clean(ctx); err != nil {
 		t.Fatal("failed to clean leaderboard", err.Error())
 	}
@@ -68,6 +81,21 @@ func getRank(
 	}
 }
 
+// listAll get id, score and rank of all members to compare leaderboards.
+func listAll(t *testing.T, ctx context.Context, leaderboard Leaderboard, order Order) []string {
+	members, cursor, err := leaderboard.List(ctx, 0, 1000, order)
+	if err != nil {
+		t.Fatal("failed to list members", err.Error())
+	}
+
+	list := []string{fmt.Sprintf("%+v", cursor)}
+	for _, member := range members {
+		list = append(list, fmt.Sprintf("%v:%v:#%v", member.ID, member.Score, member.Rank))
+	}
+
+	return list
+}
+
 func addMember(t *testing.T, ctx context.Context, leaderboard Leaderboard, id interface{}, score int) {
 	err := leaderboard.AddMember(ctx, id, score)
 	if err != nil {
@@ -246,3 +274,747 @@ func TestGetAround(t *testing.T) {
 		clean(t, ctx, leaderboard)
 	}
 }
+
+func TestGetAroundSameRankIntID(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	ctx := context.Background()
+	leaderboard := NewLeaderBoard(redisClient, "test", &Options{AllowSameRank: true})
+	defer clean(t, ctx, leaderboard)
+
+	for id := 0; id < 10; id++ {
+		addMember(t, ctx, leaderboard, id, 10-id)
+	}
+
+	// AnyCodec decodes ids to strings, so 6 is listed as "6"
+	list, cursor, err := leaderboard.GetAround(ctx, 6, 2, OrderDesc)
+	if err != nil {
+		t.Fatal("failed to get around", err.Error())
+	}
+
+	if len(list) != 2 || list[0].ID != "5" || cursor.Begin != 5 || cursor.End != 7 {
+		t.Errorf("Error in get around of int id\nExpected: %v at cursor [%v, %v)\nReceived: %+v at cursor %+v", "5", 5, 7, list, cursor)
+	}
+}
+
+func TestIncrementScore(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	testCases := []Options{
+		{
+			AllowSameRank: false,
+		},
+		{
+			AllowSameRank: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		ctx := context.Background()
+		numberOfMember := 10
+		leaderboard := initLeaderboard(t, ctx, numberOfMember, &tc)
+		defer clean(t, ctx, leaderboard)
+
+		player := "P9"
+		member, err := leaderboard.IncrementScore(ctx, player, 5)
+		if err != nil {
+			t.Error("failed to increment score", err.Error())
+			return
+		}
+
+		if member.Score != 6 {
+			t.Errorf("Error in increment score of member\nExpected: score %v\nReceived: score %v", 6, member.Score)
+		}
+
+		if member.Rank != 5 {
+			t.Errorf("Error in increment score of member\nExpected: rank #%v\nReceived: rank #%v", 5, member.Rank)
+		}
+		getRank(t, ctx, leaderboard, player, member.Rank)
+
+		newPlayer := "PNew"
+		member, err = leaderboard.IncrementScore(ctx, newPlayer, 100)
+		if err != nil {
+			t.Error("failed to increment score", err.Error())
+			return
+		}
+
+		if member.Score != 100 || member.Rank != 1 {
+			t.Errorf("Error in increment score of new member\nExpected: score %v, rank #%v\nReceived: score %v, rank #%v", 100, 1, member.Score, member.Rank)
+		}
+		clean(t, ctx, leaderboard)
+	}
+}
+
+func TestRemoveMember(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	testCases := []Options{
+		{
+			AllowSameRank: false,
+		},
+		{
+			AllowSameRank: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		ctx := context.Background()
+		numberOfMember := 10
+		leaderboard := initLeaderboard(t, ctx, numberOfMember, &tc)
+		defer clean(t, ctx, leaderboard)
+
+		if err := leaderboard.RemoveMember(ctx, "P0", "P1", "PUnknown"); err != nil {
+			t.Error("failed to remove member", err.Error())
+			return
+		}
+
+		members, _, err := leaderboard.List(ctx, 0, numberOfMember, OrderDesc)
+		if err != nil {
+			t.Error("failed to list members", err.Error())
+			return
+		}
+
+		if len(members) != numberOfMember-2 {
+			t.Errorf("something went wrong when remove member\nExpected: %v members\nReceived: %v members", numberOfMember-2, len(members))
+		}
+
+		getRank(t, ctx, leaderboard, "P2", 1)
+		clean(t, ctx, leaderboard)
+	}
+}
+
+func TestRemoveMemberSameRank(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	ctx := context.Background()
+	numberOfMember := 10
+	leaderboard := initLeaderboard(t, ctx, numberOfMember, &Options{AllowSameRank: true})
+	defer clean(t, ctx, leaderboard)
+
+	// P0 keeps score 10 in rank set after PSame is removed
+	addMember(t, ctx, leaderboard, "PSame", 10)
+	if err := leaderboard.RemoveMember(ctx, "PSame"); err != nil {
+		t.Error("failed to remove member", err.Error())
+		return
+	}
+	getRank(t, ctx, leaderboard, "P0", 1)
+	getRank(t, ctx, leaderboard, "P1", 2)
+
+	// score 10 is not held by anyone, so every rank moves up
+	if err := leaderboard.RemoveMember(ctx, "P0"); err != nil {
+		t.Error("failed to remove member", err.Error())
+		return
+	}
+	getRank(t, ctx, leaderboard, "P1", 1)
+	getRank(t, ctx, leaderboard, "P9", 9)
+}
+
+func TestGetMember(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	testCases := []Options{
+		{
+			AllowSameRank: false,
+		},
+		{
+			AllowSameRank: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		ctx := context.Background()
+		numberOfMember := 10
+		leaderboard := initLeaderboard(t, ctx, numberOfMember, &tc)
+		defer clean(t, ctx, leaderboard)
+
+		player := "P3"
+		member, err := leaderboard.GetMember(ctx, player)
+		if err != nil {
+			t.Error("failed to get member", err.Error())
+			return
+		}
+
+		if member.ID != player || member.Score != 7 || member.Rank != 4 {
+			t.Errorf("Error in get member\nExpected: %v with score %v, rank #%v\nReceived: %v with score %v, rank #%v", player, 7, 4, member.ID, member.Score, member.Rank)
+		}
+
+		_, err = leaderboard.GetMember(ctx, "PUnknown")
+		if !errors.Is(err, ErrMemberNotFound) {
+			t.Errorf("Error in get unknown member\nExpected: %v\nReceived: %v", ErrMemberNotFound, err)
+		}
+		clean(t, ctx, leaderboard)
+	}
+}
+
+func TestAddMembers(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	testCases := []Options{
+		{
+			AllowSameRank: false,
+		},
+		{
+			AllowSameRank: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		ctx := context.Background()
+		numberOfMember := 10
+		leaderboard := initLeaderboard(t, ctx, numberOfMember, &tc)
+		defer clean(t, ctx, leaderboard)
+
+		// P0 and PTop leave score 100 in the same batch
+		addMember(t, ctx, leaderboard, "PTop", 100)
+		err := leaderboard.AddMembers(ctx, []Member{
+			{ID: "P0", Score: 100},
+			{ID: "P0", Score: 1},
+			{ID: "PTop", Score: 1},
+			{ID: "PNew", Score: 50},
+		})
+		if err != nil {
+			t.Error("failed to add members", err.Error())
+			return
+		}
+
+		members, _, err := leaderboard.List(ctx, 0, numberOfMember+2, OrderDesc)
+		if err != nil {
+			t.Error("failed to list members", err.Error())
+			return
+		}
+
+		if len(members) != numberOfMember+2 {
+			t.Errorf("something went wrong when add members\nExpected: %v members\nReceived: %v members", numberOfMember+2, len(members))
+		}
+
+		getRank(t, ctx, leaderboard, "PNew", 1)
+		getRank(t, ctx, leaderboard, "P1", 2)
+		clean(t, ctx, leaderboard)
+	}
+}
+
+func TestLifeTime(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	testCases := []Options{
+		{
+			AllowSameRank: false,
+			LifeTime:      90 * time.Second,
+		},
+		{
+			AllowSameRank: true,
+			LifeTime:      90 * time.Second,
+		},
+		{
+			// a LifeTime less than a second is a number of seconds
+			AllowSameRank: true,
+			LifeTime:      90,
+		},
+	}
+
+	for _, tc := range testCases {
+		ctx := context.Background()
+		leaderboard := NewLeaderBoard(redisClient, "test", &tc)
+		defer clean(t, ctx, leaderboard)
+
+		addMember(t, ctx, leaderboard, "P1", 10)
+
+		redisLeaderboard := leaderboard.(*RedisLeaderboard)
+		keys := []string{redisLeaderboard.rankSet}
+		if tc.AllowSameRank {
+			keys = append(keys, redisLeaderboard.memberScoreSet)
+		}
+
+		for _, key := range keys {
+			ttl, err := redisClient.TTL(ctx, key).Result()
+			if err != nil || ttl <= time.Minute || ttl > 90*time.Second {
+				t.Errorf("Error in lifetime of %v, same rank %v with %v\nExpected: %v\nReceived: %v, %v", key, tc.AllowSameRank, int64(tc.LifeTime), 90*time.Second, ttl, err)
+			}
+		}
+		clean(t, ctx, leaderboard)
+	}
+}
+
+func TestGetMembers(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	testCases := []Options{
+		{
+			AllowSameRank: false,
+		},
+		{
+			AllowSameRank: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		ctx := context.Background()
+		numberOfMember := 10
+		leaderboard := initLeaderboard(t, ctx, numberOfMember, &tc)
+		defer clean(t, ctx, leaderboard)
+
+		ids := []interface{}{"P5", "PUnknown", "P0"}
+		members, err := leaderboard.GetMembers(ctx, ids)
+		if err != nil {
+			t.Error("failed to get members", err.Error())
+			return
+		}
+
+		if len(members) != len(ids) {
+			t.Errorf("Error in get members\nExpected: %v members\nReceived: %v members", len(ids), len(members))
+			return
+		}
+
+		if members[1] != nil {
+			t.Errorf("Error in get unknown member\nExpected: nil\nReceived: %v", members[1])
+		}
+
+		if members[0] == nil || members[0].ID != "P5" || members[0].Score != 5 || members[0].Rank != 6 {
+			t.Errorf("Error in get members\nExpected: P5 with score 5, rank #6\nReceived: %+v", members[0])
+		}
+
+		if members[2] == nil || members[2].ID != "P0" || members[2].Rank != 1 {
+			t.Errorf("Error in get members\nExpected: P0 with rank #1\nReceived: %+v", members[2])
+		}
+		clean(t, ctx, leaderboard)
+	}
+}
+
+func TestListByScore(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	testCases := []Options{
+		{
+			AllowSameRank: false,
+		},
+		{
+			AllowSameRank: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		ctx := context.Background()
+		numberOfMember := 10
+		leaderboard := initLeaderboard(t, ctx, numberOfMember, &tc)
+		defer clean(t, ctx, leaderboard)
+
+		members, cursor, err := leaderboard.ListByScore(ctx, Inclusive(3), Exclusive(7), 0, numberOfMember, OrderDesc)
+		if err != nil {
+			t.Error("failed to list members by score", err.Error())
+			return
+		}
+
+		if len(members) != 4 || cursor.End != 4 {
+			t.Errorf("Error in list members by score\nExpected: %v members\nReceived: %v members", 4, len(members))
+			return
+		}
+
+		if members[0].ID != "P4" || members[0].Score != 6 || members[0].Rank != 5 {
+			t.Errorf("Error in list members by score\nExpected: P4 with score 6, rank #5\nReceived: %+v", members[0])
+		}
+
+		members, _, err = leaderboard.ListByScore(ctx, MinScore, Inclusive(3), 1, numberOfMember, OrderAsc)
+		if err != nil {
+			t.Error("failed to list members by score", err.Error())
+			return
+		}
+
+		if len(members) != 2 || members[0].ID != "P8" || members[0].Rank != 9 {
+			t.Errorf("Error in list members by score with offset\nExpected: 2 members begin with P8 at rank #9\nReceived: %v members begin with %+v", len(members), members[0])
+		}
+		clean(t, ctx, leaderboard)
+	}
+}
+
+func TestCount(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	testCases := []Options{
+		{
+			AllowSameRank: false,
+		},
+		{
+			AllowSameRank: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		ctx := context.Background()
+		numberOfMember := 10
+		leaderboard := initLeaderboard(t, ctx, numberOfMember, &tc)
+		defer clean(t, ctx, leaderboard)
+
+		total, err := leaderboard.Count(ctx)
+		if err != nil {
+			t.Error("failed to count members", err.Error())
+			return
+		}
+
+		if total != numberOfMember {
+			t.Errorf("Error in count members\nExpected: %v\nReceived: %v", numberOfMember, total)
+		}
+
+		total, err = leaderboard.CountByScore(ctx, Exclusive(3), MaxScore)
+		if err != nil {
+			t.Error("failed to count members by score", err.Error())
+			return
+		}
+
+		if total != 7 {
+			t.Errorf("Error in count members by score\nExpected: %v\nReceived: %v", 7, total)
+		}
+
+		_, cursor, err := leaderboard.List(ctx, 0, 3, OrderDesc)
+		if err != nil {
+			t.Error("failed to list members", err.Error())
+			return
+		}
+
+		if cursor.Total != numberOfMember {
+			t.Errorf("Error in total of list cursor\nExpected: %v\nReceived: %v", numberOfMember, cursor.Total)
+		}
+
+		_, cursor = getAround(t, ctx, leaderboard, "P4", 3, 3)
+		if cursor.Total != numberOfMember {
+			t.Errorf("Error in total of get around cursor\nExpected: %v\nReceived: %v", numberOfMember, cursor.Total)
+		}
+		clean(t, ctx, leaderboard)
+	}
+}
+
+func TestGetPercentile(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	testCases := []Options{
+		{
+			AllowSameRank: false,
+		},
+		{
+			AllowSameRank: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		ctx := context.Background()
+		numberOfMember := 10
+		leaderboard := initLeaderboard(t, ctx, numberOfMember, &tc)
+		defer clean(t, ctx, leaderboard)
+
+		percentile, err := leaderboard.GetPercentile(ctx, "P2")
+		if err != nil {
+			t.Error("failed to get percentile", err.Error())
+			return
+		}
+
+		if percentile != 30 {
+			t.Errorf("Error in get percentile of member\nExpected: %v\nReceived: %v", 30, percentile)
+		}
+
+		_, err = leaderboard.GetPercentile(ctx, "PUnknown")
+		if !errors.Is(err, ErrMemberNotFound) {
+			t.Errorf("Error in get percentile of unknown member\nExpected: %v\nReceived: %v", ErrMemberNotFound, err)
+		}
+
+		_, err = leaderboard.GetRank(ctx, "PUnknown")
+		if !errors.Is(err, ErrMemberNotFound) {
+			t.Errorf("Error in get rank of unknown member\nExpected: %v\nReceived: %v", ErrMemberNotFound, err)
+		}
+		clean(t, ctx, leaderboard)
+	}
+}
+
+func TestSameRankingPercentile(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	ctx := context.Background()
+	leaderboard := initLeaderboard(t, ctx, 4, &Options{AllowSameRank: true})
+	defer clean(t, ctx, leaderboard)
+
+	// scores are 4, 3, 3, 2, 1 so there are 4 distinct ranks
+	addMember(t, ctx, leaderboard, "PSame", 3)
+
+	percentile, err := leaderboard.GetPercentile(ctx, "PSame")
+	if err != nil {
+		t.Error("failed to get percentile", err.Error())
+		return
+	}
+
+	if percentile != 50 {
+		t.Errorf("Error in get percentile of member with same rank\nExpected: %v\nReceived: %v", 50, percentile)
+	}
+}
+
+func TestRankingScheme(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	testCases := []struct {
+		scheme RankingScheme
+		ranks  []int
+	}{
+		{
+			scheme: RankOrdinal,
+			ranks:  []int{1, 2, 3, 4, 5, 6},
+		},
+		{
+			scheme: RankDense,
+			ranks:  []int{1, 1, 2, 2, 2, 3},
+		},
+		{
+			scheme: RankStandardCompetition,
+			ranks:  []int{1, 1, 3, 3, 3, 6},
+		},
+		{
+			scheme: RankModifiedCompetition,
+			ranks:  []int{2, 2, 5, 5, 5, 6},
+		},
+	}
+
+	for _, tc := range testCases {
+		ctx := context.Background()
+		leaderboard := NewLeaderBoard(redisClient, "test", &Options{RankingScheme: tc.scheme})
+		defer clean(t, ctx, leaderboard)
+
+		scores := []int{10, 10, 8, 8, 8, 5}
+		for idx, score := range scores {
+			addMember(t, ctx, leaderboard, fmt.Sprintf("P%v", idx), score)
+		}
+
+		members, _, err := leaderboard.List(ctx, 0, len(scores), OrderDesc)
+		if err != nil {
+			t.Error("failed to list members", err.Error())
+			return
+		}
+
+		for idx, member := range members {
+			if member.Rank != tc.ranks[idx] {
+				t.Errorf("Error in list members with %v ranking\nExpected: %v at rank #%v\nReceived: %v at rank #%v", tc.scheme, member.ID, tc.ranks[idx], member.ID, member.Rank)
+			}
+			getRank(t, ctx, leaderboard, member.ID, tc.ranks[idx])
+		}
+
+		list, _ := getAround(t, ctx, leaderboard, "P5", 2, 2)
+		if list != nil && list[1].Rank != tc.ranks[5] {
+			t.Errorf("Error in get around with %v ranking\nExpected: rank #%v\nReceived: rank #%v", tc.scheme, tc.ranks[5], list[1].Rank)
+		}
+		clean(t, ctx, leaderboard)
+	}
+}
+
+// expectUnknownConfig check err of a leaderboard created with an unknown config is about the unknown value.
+func expectUnknownConfig(t *testing.T, name string, value string, err error) {
+	t.Helper()
+	if err == nil || !strings.Contains(err.Error(), value) {
+		t.Errorf("Error in %v leaderboard with unknown config\nExpected: error about %q\nReceived: %v", name, value, err)
+	}
+}
+
+// unknownConfigErrors get errors of every leaderboard created with opts, leaderboards whose constructor doesn't
+// return errors return it from their operations.
+func unknownConfigErrors(opts Options) map[string]error {
+	ctx := context.Background()
+	errs := map[string]error{}
+	boards := map[string]Leaderboard{
+		"redis":  NewLeaderBoard(nil, "test", &opts),
+		"memory": NewMemoryLeaderBoard(&opts),
+		"bolt":   NewBoltLeaderBoard(nil, "test", &opts),
+		"sql":    NewSQLLeaderBoard(nil, SQLiteDialect, "test", &opts),
+	}
+	for name, leaderboard := range boards {
+		errs[name+" add member"] = leaderboard.AddMember(ctx, "P1", 10)
+		_, errs[name+" get rank"] = leaderboard.GetRank(ctx, "P1")
+	}
+
+	_, errs["periodic"] = NewPeriodicLeaderBoard(nil, "test", &PeriodicOptions{Options: opts})
+	_, errs["season"] = NewSeasonLeaderBoard(nil, "test", &SeasonOptions{Options: opts})
+	_, errs["window"] = NewWindowLeaderBoard(nil, "test", &WindowOptions{Options: opts})
+	_, errs["aggregate"] = NewAggregateLeaderBoard(nil, "test", &AggregateOptions{Boards: []string{"a"}, Options: opts})
+
+	return errs
+}
+
+func TestUnknownRankingScheme(t *testing.T) {
+	for name, err := range unknownConfigErrors(Options{RankingScheme: "dense_rank"}) {
+		expectUnknownConfig(t, name, "dense_rank", err)
+	}
+}
+
+func TestUpdatePolicy(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	testCases := []struct {
+		opts     Options
+		policy   UpdatePolicy
+		scores   []int
+		expected int
+		changed  []bool
+	}{
+		{
+			opts:     Options{},
+			policy:   UpdateReplace,
+			scores:   []int{10, 5, 5, 20},
+			expected: 20,
+			changed:  []bool{true, true, false, true},
+		},
+		{
+			opts:     Options{},
+			policy:   UpdateKeepHighest,
+			scores:   []int{10, 5, 20, 15},
+			expected: 20,
+			changed:  []bool{true, false, true, false},
+		},
+		{
+			opts:     Options{RankingScheme: RankDense},
+			policy:   UpdateKeepLowest,
+			scores:   []int{10, 5, 20, 5},
+			expected: 5,
+			changed:  []bool{true, true, false, false},
+		},
+		{
+			opts:     Options{RankingScheme: RankDense},
+			policy:   UpdateSum,
+			scores:   []int{10, 5, 0, 20},
+			expected: 35,
+			changed:  []bool{true, true, false, true},
+		},
+		{
+			opts:     Options{TieBreak: TieBreakEarliest},
+			policy:   UpdateSum,
+			scores:   []int{10, -5, 5},
+			expected: 10,
+			changed:  []bool{true, true, true},
+		},
+	}
+
+	for _, tc := range testCases {
+		ctx := context.Background()
+		leaderboard := initLeaderboard(t, ctx, 3, &tc.opts)
+		defer clean(t, ctx, leaderboard)
+
+		player := "PPolicy"
+		for idx, score := range tc.scores {
+			changed, err := leaderboard.UpdateMember(ctx, player, score, tc.policy)
+			if err != nil {
+				t.Error("failed to update member", err.Error())
+				return
+			}
+
+			if changed != tc.changed[idx] {
+				t.Errorf("Error in update member with %v policy and score %v\nExpected: changed %v\nReceived: changed %v", tc.policy, score, tc.changed[idx], changed)
+			}
+		}
+
+		member, err := leaderboard.GetMember(ctx, player)
+		if err != nil {
+			t.Error("failed to get member", err.Error())
+			return
+		}
+
+		if member.Score != tc.expected {
+			t.Errorf("Error in update member with %v policy\nExpected: score %v\nReceived: score %v", tc.policy, tc.expected, member.Score)
+		}
+		clean(t, ctx, leaderboard)
+	}
+
+	// policy of options is used by AddMember and AddMembers
+	ctx := context.Background()
+	leaderboard := initLeaderboard(t, ctx, 3, &Options{UpdatePolicy: UpdateKeepHighest, RankingScheme: RankDense})
+	defer clean(t, ctx, leaderboard)
+
+	addMember(t, ctx, leaderboard, "P0", 1)
+	if err := leaderboard.AddMembers(ctx, []Member{{ID: "P1", Score: 100}, {ID: "P2", Score: 0}}); err != nil {
+		t.Error("failed to add members", err.Error())
+		return
+	}
+
+	members, err := leaderboard.GetMembers(ctx, []interface{}{"P0", "P1", "P2"})
+	if err != nil {
+		t.Error("failed to get members", err.Error())
+		return
+	}
+
+	for idx, expected := range []int{3, 100, 1} {
+		if members[idx].Score != expected {
+			t.Errorf("Error in add member with keep highest policy\nExpected: %v with score %v\nReceived: score %v", members[idx].ID, expected, members[idx].Score)
+		}
+	}
+}
+
+func TestUnknownUpdatePolicy(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	for name, err := range unknownConfigErrors(Options{UpdatePolicy: "keep_best"}) {
+		expectUnknownConfig(t, name, "keep_best", err)
+	}
+
+	boltDB := openBolt(t, filepath.Join(t.TempDir(), "leaderboard.db"))
+	defer boltDB.Close()
+	sqlDB := openSQLite(t)
+	defer sqlDB.Close()
+
+	ctx := context.Background()
+	for _, leaderboard := range []Leaderboard{
+		NewLeaderBoard(redisClient, "test", nil),
+		NewMemoryLeaderBoard(nil),
+		NewBoltLeaderBoard(boltDB, "test", nil),
+		NewSQLLeaderBoard(sqlDB, SQLiteDialect, "test", nil),
+	} {
+		updated, err := leaderboard.UpdateMember(ctx, "P1", 10, "keep_best")
+		if err == nil || !strings.Contains(err.Error(), "keep_best") || updated {
+			t.Errorf("Error in update member with unknown policy\nExpected: error about %q\nReceived: %v, %v", "keep_best", updated, err)
+		}
+
+		if _, err := leaderboard.GetRank(ctx, "P1"); !errors.Is(err, ErrMemberNotFound) {
+			t.Errorf("Error in get rank of member updated with unknown policy\nExpected: %v\nReceived: %v", ErrMemberNotFound, err)
+		}
+	}
+}
+
+func TestHashTag(t *testing.T) {
+	testCases := []struct {
+		name        string
+		clusterKeys bool
+		expected    string
+	}{
+		{
+			name:     "test",
+			expected: "goleaderboard:test:rank_set",
+		},
+		{
+			name:        "test",
+			clusterKeys: true,
+			expected:    "goleaderboard:{test}:rank_set",
+		},
+		{
+			name:        "{kills}:solo",
+			clusterKeys: true,
+			expected:    "goleaderboard:{kills}:solo:rank_set",
+		},
+		{
+			name:        "kills:{}",
+			clusterKeys: true,
+			expected:    "goleaderboard:{kills:{}}:rank_set",
+		},
+	}
+
+	for _, tc := range testCases {
+		leaderboard := newTypedRedisLeaderBoard[interface{}, int](redisClient, tc.name, AnyCodec{}, &Options{ClusterKeys: tc.clusterKeys})
+		if leaderboard.rankSet != tc.expected {
+			t.Errorf("Error in key of leaderboard %q with cluster keys %v\nExpected: %v\nReceived: %v", tc.name, tc.clusterKeys, tc.expected, leaderboard.rankSet)
+		}
+	}
+}
@@ -0,0 +1,549 @@
+// Package leaderboardtest checks implementations of goleaderboard.Leaderboard against the same expectations,
+// so wrappers and new backends rank, order and list members like the leaderboards of goleaderboard.
+//
+//	func TestMyLeaderboard(t *testing.T) {
+//		leaderboardtest.Run(t, func(t *testing.T, opts *goleaderboard.Options) goleaderboard.Leaderboard {
+//			return NewMyLeaderboard(t.Name(), opts)
+//		})
+//	}
+package leaderboardtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/duysmile/goleaderboard"
+)
+
+// Factory create a leaderboard with configs for a test.
+// Leaderboards of different tests must not share data, for example they can be named by t.Name().
+type Factory func(t *testing.T, opts *goleaderboard.Options) goleaderboard.Leaderboard
+
+// Run check leaderboards created by factory against all expectations, every group of them is a subtest of t.
+// Leaderboards are cleaned before and after every test.
+func Run(t *testing.T, factory Factory) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, factory Factory)
+	}{
+		{"Ranks", testRanks},
+		{"Ordering", testOrdering},
+		{"Cursors", testCursors},
+		{"GetAround", testGetAround},
+		{"ListByScore", testListByScore},
+		{"Members", testMembers},
+		{"UpdatePolicy", testUpdatePolicy},
+		{"IncrementScore", testIncrementScore},
+		{"Metadata", testMetadata},
+		{"Subset", testSubset},
+		{"Clean", testClean},
+		{"LifeTime", testLifeTime},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			tc.test(t, factory)
+		})
+	}
+}
+
+type entry struct {
+	id    string
+	score int
+}
+
+// ties are members with the same score, their ranks by every scheme are in schemes.
+var ties = []entry{{"P0", 50}, {"P1", 40}, {"P2", 40}, {"P3", 30}, {"P4", 20}}
+
+// schemes are options of every ranking scheme with ranks of ties listed in descending order, P2 is before P1.
+var schemes = []struct {
+	name  string
+	opts  goleaderboard.Options
+	ranks []int
+}{
+	{"ordinal", goleaderboard.Options{RankingScheme: goleaderboard.RankOrdinal}, []int{1, 2, 3, 4, 5}},
+	{"dense", goleaderboard.Options{RankingScheme: goleaderboard.RankDense}, []int{1, 2, 2, 3, 4}},
+	{"allow_same_rank", goleaderboard.Options{AllowSameRank: true}, []int{1, 2, 2, 3, 4}},
+	{"standard_competition", goleaderboard.Options{RankingScheme: goleaderboard.RankStandardCompetition}, []int{1, 2, 2, 4, 5}},
+	{"modified_competition", goleaderboard.Options{RankingScheme: goleaderboard.RankModifiedCompetition}, []int{1, 3, 3, 4, 5}},
+}
+
+// tiesOrder is ids of ties listed in descending order, members with the same score are ordered by id descending.
+var tiesOrder = []string{"P0", "P2", "P1", "P3", "P4"}
+
+// sequence get members M0, M1, ... with distinct scores from size to 1.
+func sequence(size int) []entry {
+	entries := make([]entry, size)
+	for idx := range entries {
+		entries[idx] = entry{fmt.Sprintf("M%v", idx), size - idx}
+	}
+
+	return entries
+}
+
+// newLeaderboard create a leaderboard by factory with entries, it is cleaned before and after test.
+func newLeaderboard(t *testing.T, factory Factory, opts goleaderboard.Options, entries ...entry) goleaderboard.Leaderboard {
+	ctx := context.Background()
+	leaderboard := factory(t, &opts)
+	if err := leaderboard.Clean(ctx); err != nil {
+		t.Fatal("failed to clean leaderboard", err.Error())
+	}
+
+	t.Cleanup(func() {
+		if err := leaderboard.Clean(ctx); err != nil {
+			t.Error("failed to clean leaderboard", err.Error())
+		}
+	})
+
+	for _, member := range entries {
+		if err := leaderboard.AddMember(ctx, member.id, member.score); err != nil {
+			t.Fatal("failed to add member", err.Error())
+		}
+	}
+
+	return leaderboard
+}
+
+// format get members like "P0:50:#1" to compare them.
+func format(members []*goleaderboard.Member) []string {
+	list := make([]string, 0, len(members))
+	for _, member := range members {
+		if member == nil {
+			list = append(list, "<nil>")
+			continue
+		}
+		list = append(list, fmt.Sprintf("%v:%v:#%v", member.ID, member.Score, member.Rank))
+	}
+
+	return list
+}
+
+func expectList(t *testing.T, name string, members []*goleaderboard.Member, cursor goleaderboard.Cursor, err error, expected []string, expectedCursor goleaderboard.Cursor) {
+	t.Helper()
+	if err != nil {
+		t.Errorf("failed to %v: %v", name, err)
+		return
+	}
+
+	if received := format(members); !reflect.DeepEqual(received, expected) || cursor != expectedCursor {
+		t.Errorf("Error in %v\nExpected: %v, %+v\nReceived: %v, %+v", name, expected, expectedCursor, received, cursor)
+	}
+}
+
+func expectNotFound(t *testing.T, name string, err error) {
+	t.Helper()
+	if !errors.Is(err, goleaderboard.ErrMemberNotFound) {
+		t.Errorf("Error in %v\nExpected: %v\nReceived: %v", name, goleaderboard.ErrMemberNotFound, err)
+	}
+}
+
+func testRanks(t *testing.T, factory Factory) {
+	ctx := context.Background()
+	for _, scheme := range schemes {
+		scheme := scheme
+		t.Run(scheme.name, func(t *testing.T) {
+			leaderboard := newLeaderboard(t, factory, scheme.opts, ties...)
+			for idx, id := range tiesOrder {
+				rank, err := leaderboard.GetRank(ctx, id)
+				if err != nil || rank != scheme.ranks[idx] {
+					t.Errorf("Error in get rank of %v\nExpected: rank #%v\nReceived: rank #%v, %v", id, scheme.ranks[idx], rank, err)
+				}
+			}
+
+			// the last member is always at 100 percentile, with RankDense over number of distinct scores
+			total := len(ties)
+			if scheme.ranks[len(ties)-1] != total {
+				total = scheme.ranks[len(ties)-1]
+			}
+
+			for id, expected := range map[string]float64{"P0": 100 / float64(total), "P4": 100} {
+				percentile, err := leaderboard.GetPercentile(ctx, id)
+				if err != nil || percentile != expected {
+					t.Errorf("Error in get percentile of %v\nExpected: %v\nReceived: %v, %v", id, expected, percentile, err)
+				}
+			}
+
+			_, err := leaderboard.GetPercentile(ctx, "unknown")
+			expectNotFound(t, "get percentile of unknown member", err)
+		})
+	}
+}
+
+func testOrdering(t *testing.T, factory Factory) {
+	ctx := context.Background()
+	for _, scheme := range schemes {
+		scheme := scheme
+		t.Run(scheme.name, func(t *testing.T) {
+			leaderboard := newLeaderboard(t, factory, scheme.opts, ties...)
+			scores := map[string]int{}
+			for _, member := range ties {
+				scores[member.id] = member.score
+			}
+
+			expected := make([]string, len(tiesOrder))
+			for idx, id := range tiesOrder {
+				expected[idx] = fmt.Sprintf("%v:%v:#%v", id, scores[id], scheme.ranks[idx])
+			}
+
+			members, cursor, err := leaderboard.List(ctx, 0, 10, goleaderboard.OrderDesc)
+			expectList(t, "list member desc", members, cursor, err, expected, goleaderboard.Cursor{Begin: 0, End: 5, Total: 5})
+
+			reversed := make([]string, len(expected))
+			for idx := range expected {
+				reversed[idx] = expected[len(expected)-1-idx]
+			}
+
+			members, cursor, err = leaderboard.List(ctx, 0, 10, goleaderboard.OrderAsc)
+			expectList(t, "list member asc", members, cursor, err, reversed, goleaderboard.Cursor{Begin: 0, End: 5, Total: 5})
+		})
+	}
+}
+
+func testCursors(t *testing.T, factory Factory) {
+	ctx := context.Background()
+	leaderboard := newLeaderboard(t, factory, goleaderboard.Options{}, sequence(10)...)
+
+	testCases := []struct {
+		offset   int
+		limit    int
+		order    goleaderboard.Order
+		expected []string
+		cursor   goleaderboard.Cursor
+	}{
+		{0, 3, goleaderboard.OrderDesc, []string{"M0:10:#1", "M1:9:#2", "M2:8:#3"}, goleaderboard.Cursor{Begin: 0, End: 3, Total: 10}},
+		{3, 3, goleaderboard.OrderDesc, []string{"M3:7:#4", "M4:6:#5", "M5:5:#6"}, goleaderboard.Cursor{Begin: 3, End: 6, Total: 10}},
+		{8, 5, goleaderboard.OrderDesc, []string{"M8:2:#9", "M9:1:#10"}, goleaderboard.Cursor{Begin: 8, End: 10, Total: 10}},
+		{10, 5, goleaderboard.OrderDesc, []string{}, goleaderboard.Cursor{Begin: 10, End: 10, Total: 10}},
+		{0, 3, goleaderboard.OrderAsc, []string{"M9:1:#10", "M8:2:#9", "M7:3:#8"}, goleaderboard.Cursor{Begin: 0, End: 3, Total: 10}},
+		{8, 5, goleaderboard.OrderAsc, []string{"M1:9:#2", "M0:10:#1"}, goleaderboard.Cursor{Begin: 8, End: 10, Total: 10}},
+	}
+
+	for _, tc := range testCases {
+		members, cursor, err := leaderboard.List(ctx, tc.offset, tc.limit, tc.order)
+		name := fmt.Sprintf("list %v members from %v %v", tc.limit, tc.offset, tc.order)
+		expectList(t, name, members, cursor, err, tc.expected, tc.cursor)
+	}
+}
+
+func testGetAround(t *testing.T, factory Factory) {
+	ctx := context.Background()
+	leaderboard := newLeaderboard(t, factory, goleaderboard.Options{}, sequence(10)...)
+
+	testCases := []struct {
+		id       string
+		limit    int
+		order    goleaderboard.Order
+		expected []string
+		cursor   goleaderboard.Cursor
+	}{
+		// the first and the last member can not be in the middle
+		{"M0", 4, goleaderboard.OrderDesc, []string{"M0:10:#1", "M1:9:#2", "M2:8:#3", "M3:7:#4"}, goleaderboard.Cursor{Begin: 0, End: 4, Total: 10}},
+		{"M9", 4, goleaderboard.OrderDesc, []string{"M6:4:#7", "M7:3:#8", "M8:2:#9", "M9:1:#10"}, goleaderboard.Cursor{Begin: 6, End: 10, Total: 10}},
+		{"M5", 3, goleaderboard.OrderDesc, []string{"M4:6:#5", "M5:5:#6", "M6:4:#7"}, goleaderboard.Cursor{Begin: 4, End: 7, Total: 10}},
+		{"M0", 3, goleaderboard.OrderAsc, []string{"M2:8:#3", "M1:9:#2", "M0:10:#1"}, goleaderboard.Cursor{Begin: 7, End: 10, Total: 10}},
+		{"M5", 3, goleaderboard.OrderAsc, []string{"M6:4:#7", "M5:5:#6", "M4:6:#5"}, goleaderboard.Cursor{Begin: 3, End: 6, Total: 10}},
+	}
+
+	for _, tc := range testCases {
+		members, cursor, err := leaderboard.GetAround(ctx, tc.id, tc.limit, tc.order)
+		name := fmt.Sprintf("get %v members around %v %v", tc.limit, tc.id, tc.order)
+		expectList(t, name, members, cursor, err, tc.expected, tc.cursor)
+	}
+
+	// limit is greater than size of leaderboard
+	members, cursor, err := leaderboard.GetAround(ctx, "M5", 20, goleaderboard.OrderDesc)
+	if err != nil || len(members) != 10 || cursor != (goleaderboard.Cursor{Begin: 0, End: 10, Total: 10}) {
+		t.Errorf("Error in get around with limit greater than size\nExpected: 10 members\nReceived: %v, %+v, %v", format(members), cursor, err)
+	}
+
+	_, _, err = leaderboard.GetAround(ctx, "unknown", 3, goleaderboard.OrderDesc)
+	expectNotFound(t, "get around unknown member", err)
+
+	for _, scheme := range schemes[1:] {
+		scheme := scheme
+		t.Run(scheme.name, func(t *testing.T) {
+			leaderboard := newLeaderboard(t, factory, scheme.opts, ties...)
+			members, cursor, err := leaderboard.GetAround(ctx, "P1", 3, goleaderboard.OrderDesc)
+			expected := []string{
+				fmt.Sprintf("P2:40:#%v", scheme.ranks[1]),
+				fmt.Sprintf("P1:40:#%v", scheme.ranks[2]),
+				fmt.Sprintf("P3:30:#%v", scheme.ranks[3]),
+			}
+			expectList(t, "get around member with the same score", members, cursor, err, expected, goleaderboard.Cursor{Begin: 1, End: 4, Total: 5})
+
+			_, _, err = leaderboard.GetAround(ctx, "unknown", 3, goleaderboard.OrderDesc)
+			expectNotFound(t, "get around unknown member", err)
+		})
+	}
+}
+
+func testListByScore(t *testing.T, factory Factory) {
+	ctx := context.Background()
+	leaderboard := newLeaderboard(t, factory, goleaderboard.Options{}, sequence(10)...)
+
+	members, cursor, err := leaderboard.ListByScore(ctx, goleaderboard.Inclusive(3), goleaderboard.Exclusive(8), 0, 10, goleaderboard.OrderDesc)
+	expected := []string{"M3:7:#4", "M4:6:#5", "M5:5:#6", "M6:4:#7", "M7:3:#8"}
+	expectList(t, "list member by score", members, cursor, err, expected, goleaderboard.Cursor{Begin: 0, End: 5, Total: 5})
+
+	members, cursor, err = leaderboard.ListByScore(ctx, goleaderboard.Inclusive(3), goleaderboard.Exclusive(8), 2, 2, goleaderboard.OrderAsc)
+	expected = []string{"M5:5:#6", "M4:6:#5"}
+	expectList(t, "list member by score from offset", members, cursor, err, expected, goleaderboard.Cursor{Begin: 2, End: 4, Total: 5})
+
+	members, cursor, err = leaderboard.ListByScore(ctx, goleaderboard.Exclusive(10), goleaderboard.MaxScore, 0, 10, goleaderboard.OrderDesc)
+	expectList(t, "list member by empty score range", members, cursor, err, []string{}, goleaderboard.Cursor{Begin: 0, End: 0, Total: 0})
+
+	testCases := []struct {
+		min      goleaderboard.ScoreBound
+		max      goleaderboard.ScoreBound
+		expected int
+	}{
+		{goleaderboard.MinScore, goleaderboard.MaxScore, 10},
+		{goleaderboard.MinScore, goleaderboard.Inclusive(5), 5},
+		{goleaderboard.Exclusive(5), goleaderboard.MaxScore, 5},
+		{goleaderboard.Exclusive(10), goleaderboard.MaxScore, 0},
+	}
+
+	for _, tc := range testCases {
+		total, err := leaderboard.CountByScore(ctx, tc.min, tc.max)
+		if err != nil || total != tc.expected {
+			t.Errorf("Error in count member by score in [%v, %v]\nExpected: %v\nReceived: %v, %v", tc.min, tc.max, tc.expected, total, err)
+		}
+	}
+}
+
+func testMembers(t *testing.T, factory Factory) {
+	ctx := context.Background()
+	leaderboard := newLeaderboard(t, factory, goleaderboard.Options{}, ties...)
+
+	total, err := leaderboard.Count(ctx)
+	if err != nil || total != len(ties) {
+		t.Errorf("Error in count member\nExpected: %v\nReceived: %v, %v", len(ties), total, err)
+	}
+
+	member, err := leaderboard.GetMember(ctx, "P3")
+	if err != nil || member.ID != "P3" || member.Score != 30 || member.Rank != 4 {
+		t.Errorf("Error in get member\nExpected: P3:30:#4\nReceived: %+v, %v", member, err)
+	}
+
+	_, err = leaderboard.GetMember(ctx, "unknown")
+	expectNotFound(t, "get unknown member", err)
+
+	_, err = leaderboard.GetRank(ctx, "unknown")
+	expectNotFound(t, "get rank of unknown member", err)
+
+	members, err := leaderboard.GetMembers(ctx, []interface{}{"P1", "unknown", "P4"})
+	if received := format(members); err != nil || !reflect.DeepEqual(received, []string{"P1:40:#3", "<nil>", "P4:20:#5"}) {
+		t.Errorf("Error in get members\nExpected: [P1:40:#3 <nil> P4:20:#5]\nReceived: %v, %v", received, err)
+	}
+
+	member, err = leaderboard.IncrementScore(ctx, "P4", 25)
+	if err != nil || member.Score != 45 || member.Rank != 2 {
+		t.Errorf("Error in increment score\nExpected: P4:45:#2\nReceived: %+v, %v", member, err)
+	}
+
+	member, err = leaderboard.IncrementScore(ctx, "P5", 5)
+	if err != nil || member.Score != 5 || member.Rank != 6 {
+		t.Errorf("Error in increment score of new member\nExpected: P5:5:#6\nReceived: %+v, %v", member, err)
+	}
+
+	if err := leaderboard.RemoveMember(ctx, "P0", "P5", "unknown"); err != nil {
+		t.Fatal("failed to remove member", err.Error())
+	}
+
+	members, cursor, err := leaderboard.List(ctx, 0, 10, goleaderboard.OrderDesc)
+	expected := []string{"P4:45:#1", "P2:40:#2", "P1:40:#3", "P3:30:#4"}
+	expectList(t, "list member after remove", members, cursor, err, expected, goleaderboard.Cursor{Begin: 0, End: 4, Total: 4})
+}
+
+func testUpdatePolicy(t *testing.T, factory Factory) {
+	ctx := context.Background()
+	testCases := []struct {
+		policy   goleaderboard.UpdatePolicy
+		expected int
+	}{
+		{goleaderboard.UpdateReplace, 5},
+		{goleaderboard.UpdateKeepHighest, 10},
+		{goleaderboard.UpdateKeepLowest, 5},
+		{goleaderboard.UpdateSum, 15},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(string(tc.policy), func(t *testing.T) {
+			leaderboard := newLeaderboard(t, factory, goleaderboard.Options{UpdatePolicy: tc.policy}, entry{"P1", 10}, entry{"P1", 5})
+			member, err := leaderboard.GetMember(ctx, "P1")
+			if err != nil || member.Score != tc.expected {
+				t.Errorf("Error in update score\nExpected: %v\nReceived: %+v, %v", tc.expected, member, err)
+			}
+
+			// UpdateMember reports whether the score was changed
+			updated, err := leaderboard.UpdateMember(ctx, "P1", 100, goleaderboard.UpdateKeepLowest)
+			if err != nil || updated {
+				t.Errorf("Error in update member with a higher score\nExpected: not updated\nReceived: %v, %v", updated, err)
+			}
+
+			updated, err = leaderboard.UpdateMember(ctx, "P1", 100, goleaderboard.UpdateKeepHighest)
+			if err != nil || !updated {
+				t.Errorf("Error in update member with a higher score\nExpected: updated\nReceived: %v, %v", updated, err)
+			}
+		})
+	}
+}
+
+func testIncrementScore(t *testing.T, factory Factory) {
+	ctx := context.Background()
+	// ranks of P3 incremented to the top score of P0 and of P9 added with the lowest score
+	ranks := map[string][]int{
+		"ordinal":              {1, 6},
+		"dense":                {1, 4},
+		"allow_same_rank":      {1, 4},
+		"standard_competition": {1, 6},
+		"modified_competition": {2, 6},
+	}
+
+	for _, scheme := range schemes {
+		scheme := scheme
+		t.Run(scheme.name, func(t *testing.T) {
+			leaderboard := newLeaderboard(t, factory, scheme.opts, ties...)
+			expected := ranks[scheme.name]
+
+			member, err := leaderboard.IncrementScore(ctx, "P3", 20)
+			if err != nil || member.ID != "P3" || member.Score != 50 || member.Rank != expected[0] {
+				t.Errorf("Error in increment score\nExpected: P3 with score 50 at rank #%v\nReceived: %+v, %v", expected[0], member, err)
+			}
+
+			member, err = leaderboard.IncrementScore(ctx, "P9", 5)
+			if err != nil || member.ID != "P9" || member.Score != 5 || member.Rank != expected[1] {
+				t.Errorf("Error in increment score of new member\nExpected: P9 with score 5 at rank #%v\nReceived: %+v, %v", expected[1], member, err)
+			}
+
+			member, err = leaderboard.GetMember(ctx, "P3")
+			if err != nil || member.Score != 50 || member.Rank != expected[0] {
+				t.Errorf("Error in get member after increment score\nExpected: P3 with score 50 at rank #%v\nReceived: %+v, %v", expected[0], member, err)
+			}
+		})
+	}
+}
+
+func testMetadata(t *testing.T, factory Factory) {
+	ctx := context.Background()
+	leaderboard := newLeaderboard(t, factory, goleaderboard.Options{IncludeMetadata: true}, entry{"P2", 20})
+	if err := leaderboard.AddMemberWithMetadata(ctx, "P1", 10, map[string]string{"name": "Duy"}); err != nil {
+		t.Fatal("failed to add member with metadata", err.Error())
+	}
+
+	members, _, err := leaderboard.List(ctx, 0, 10, goleaderboard.OrderDesc)
+	if err != nil || len(members) != 2 || members[0].Metadata != nil || members[1].Metadata["name"] != "Duy" {
+		t.Errorf("Error in list member with metadata\nExpected: metadata of P1\nReceived: %+v, %v", members, err)
+	}
+
+	member, err := leaderboard.GetMember(ctx, "P1")
+	if err != nil || member.Metadata["name"] != "Duy" {
+		t.Errorf("Error in get member with metadata\nExpected: metadata of P1\nReceived: %+v, %v", member, err)
+	}
+}
+
+func testSubset(t *testing.T, factory Factory) {
+	ctx := context.Background()
+	subsetRanks := map[string][]int{
+		"ordinal":              {1, 2, 3},
+		"dense":                {1, 1, 2},
+		"allow_same_rank":      {1, 1, 2},
+		"standard_competition": {1, 1, 3},
+		"modified_competition": {2, 2, 3},
+	}
+
+	for _, scheme := range schemes {
+		scheme := scheme
+		t.Run(scheme.name, func(t *testing.T) {
+			leaderboard := newLeaderboard(t, factory, scheme.opts, ties...)
+			members, err := leaderboard.ListSubset(ctx, []interface{}{"P4", "P1", "unknown", "P2"}, goleaderboard.OrderDesc)
+			if err != nil {
+				t.Fatal("failed to list subset", err.Error())
+			}
+
+			expected := subsetRanks[scheme.name]
+			received := []string{}
+			for _, member := range members {
+				received = append(received, fmt.Sprintf("%v:#%v", member.ID, member.SubsetRank))
+			}
+
+			if !reflect.DeepEqual(received, []string{
+				fmt.Sprintf("P2:#%v", expected[0]),
+				fmt.Sprintf("P1:#%v", expected[1]),
+				fmt.Sprintf("P4:#%v", expected[2]),
+			}) {
+				t.Errorf("Error in list subset\nExpected: P2, P1, P4 at ranks %v\nReceived: %v", expected, received)
+			}
+
+			rank, err := leaderboard.GetRankInSubset(ctx, "P4", []interface{}{"P1", "P2"})
+			if err != nil || rank != expected[2] {
+				t.Errorf("Error in get rank in subset\nExpected: rank #%v\nReceived: rank #%v, %v", expected[2], rank, err)
+			}
+
+			_, err = leaderboard.GetRankInSubset(ctx, "unknown", []interface{}{"P1", "P2"})
+			expectNotFound(t, "get rank of unknown member in subset", err)
+		})
+	}
+}
+
+func testClean(t *testing.T, factory Factory) {
+	ctx := context.Background()
+	leaderboard := newLeaderboard(t, factory, goleaderboard.Options{}, sequence(5)...)
+	if err := leaderboard.Clean(ctx); err != nil {
+		t.Fatal("failed to clean leaderboard", err.Error())
+	}
+
+	total, err := leaderboard.Count(ctx)
+	if err != nil || total != 0 {
+		t.Errorf("Error in count member of cleaned leaderboard\nExpected: 0\nReceived: %v, %v", total, err)
+	}
+
+	members, cursor, err := leaderboard.List(ctx, 0, 10, goleaderboard.OrderDesc)
+	expectList(t, "list member of cleaned leaderboard", members, cursor, err, []string{}, goleaderboard.Cursor{Begin: 0, End: 0, Total: 0})
+
+	_, err = leaderboard.GetRank(ctx, "M0")
+	expectNotFound(t, "get rank of member of cleaned leaderboard", err)
+
+	if err := leaderboard.AddMember(ctx, "M4", 1); err != nil {
+		t.Fatal("failed to add member", err.Error())
+	}
+
+	rank, err := leaderboard.GetRank(ctx, "M4")
+	if err != nil || rank != 1 {
+		t.Errorf("Error in get rank after clean\nExpected: rank #1\nReceived: rank #%v, %v", rank, err)
+	}
+}
+
+// testLifeTime wait for the leaderboard to expire in real time, LifeTime is 1 second because Redis expires keys by seconds.
+func testLifeTime(t *testing.T, factory Factory) {
+	ctx := context.Background()
+	leaderboard := newLeaderboard(t, factory, goleaderboard.Options{LifeTime: time.Second}, ties...)
+
+	total, err := leaderboard.Count(ctx)
+	if err != nil || total != len(ties) {
+		t.Errorf("Error in count member before leaderboard expires\nExpected: %v\nReceived: %v, %v", len(ties), total, err)
+	}
+
+	time.Sleep(1500 * time.Millisecond)
+
+	total, err = leaderboard.Count(ctx)
+	if err != nil || total != 0 {
+		t.Errorf("Error in count member of expired leaderboard\nExpected: 0\nReceived: %v, %v", total, err)
+	}
+
+	_, err = leaderboard.GetRank(ctx, "P0")
+	expectNotFound(t, "get rank of member of expired leaderboard", err)
+
+	if err := leaderboard.AddMember(ctx, "P1", 10); err != nil {
+		t.Fatal("failed to add member", err.Error())
+	}
+
+	members, cursor, err := leaderboard.List(ctx, 0, 10, goleaderboard.OrderDesc)
+	expectList(t, "list member after expired leaderboard is written", members, cursor, err, []string{"P1:10:#1"}, goleaderboard.Cursor{Begin: 0, End: 1, Total: 1})
+}
@@ -0,0 +1,71 @@
+package leaderboardtest_test
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/duysmile/goleaderboard"
+	"github.com/duysmile/goleaderboard/leaderboardtest"
+	"github.com/go-redis/redis/v8"
+	bolt "go.etcd.io/bbolt"
+	_ "modernc.org/sqlite"
+)
+
+// TestRedisLeaderboard run the suite on Redis at GOLEADERBOARD_REDIS_ADDR, default is localhost:6379.
+// It is skipped if Redis is not available.
+func TestRedisLeaderboard(t *testing.T) {
+	addr := os.Getenv("GOLEADERBOARD_REDIS_ADDR")
+	if addr == "" {
+		addr = "localhost:6379"
+	}
+
+	redisClient := redis.NewClient(&redis.Options{
+		Addr: addr,
+	})
+	defer redisClient.Close()
+
+	if err := redisClient.Ping(context.Background()).Err(); err != nil {
+		t.Skip("redis is not available at", addr, err.Error())
+	}
+
+	leaderboardtest.Run(t, func(t *testing.T, opts *goleaderboard.Options) goleaderboard.Leaderboard {
+		return goleaderboard.NewLeaderBoard(redisClient, "leaderboardtest:"+t.Name(), opts)
+	})
+}
+
+func TestMemoryLeaderboard(t *testing.T) {
+	leaderboardtest.Run(t, func(t *testing.T, opts *goleaderboard.Options) goleaderboard.Leaderboard {
+		return goleaderboard.NewMemoryLeaderBoard(opts)
+	})
+}
+
+func TestBoltLeaderboard(t *testing.T) {
+	db, err := bolt.Open(filepath.Join(t.TempDir(), "leaderboard.db"), 0600, nil)
+	if err != nil {
+		t.Fatal("failed to open bolt database", err.Error())
+	}
+	defer db.Close()
+
+	leaderboardtest.Run(t, func(t *testing.T, opts *goleaderboard.Options) goleaderboard.Leaderboard {
+		return goleaderboard.NewBoltLeaderBoard(db, t.Name(), opts)
+	})
+}
+
+func TestSQLLeaderboard(t *testing.T) {
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "leaderboard.db"))
+	if err != nil {
+		t.Fatal("failed to open sqlite database", err.Error())
+	}
+	defer db.Close()
+
+	if err := goleaderboard.MigrateSQL(context.Background(), db, goleaderboard.SQLiteDialect); err != nil {
+		t.Fatal("failed to migrate sqlite database", err.Error())
+	}
+
+	leaderboardtest.Run(t, func(t *testing.T, opts *goleaderboard.Options) goleaderboard.Leaderboard {
+		return goleaderboard.NewSQLLeaderBoard(db, goleaderboard.SQLiteDialect, t.Name(), opts)
+	})
+}
@@ -0,0 +1,682 @@
+package goleaderboard
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// memoryKey is the position of a member in a memory leaderboard, members are sorted by score, then by the time
+// they reached it with tie break, then by id.
+type memoryKey[S Score] struct {
+	score S
+	time  int64
+	id    string
+}
+
+func lessMemoryKey[S Score](a, b memoryKey[S]) bool {
+	if a.score != b.score {
+		return a.score < b.score
+	}
+
+	if a.time != b.time {
+		return a.time < b.time
+	}
+
+	return a.id < b.id
+}
+
+// memoryBoard is the data of a memory leaderboard.
+type memoryBoard[S Score] struct {
+	members  map[string]memoryKey[S]
+	metadata map[string]map[string]string
+	list     *skiplist[memoryKey[S]]
+	// scores keeps distinct scores with their number of members for RankDense
+	scores      *skiplist[S]
+	scoreCounts map[S]int
+}
+
+func newMemoryBoard[S Score]() *memoryBoard[S] {
+	return &memoryBoard[S]{
+		members:  make(map[string]memoryKey[S]),
+		metadata: make(map[string]map[string]string),
+		list:     newSkiplist(lessMemoryKey[S]),
+		scores: newSkiplist(func(a, b S) bool {
+			return a < b
+		}),
+		scoreCounts: make(map[S]int),
+	}
+}
+
+func (b *memoryBoard[S]) add(key memoryKey[S]) {
+	b.remove(key.id)
+
+	b.members[key.id] = key
+	b.list.Insert(key)
+	if b.scoreCounts[key.score] == 0 {
+		b.scores.Insert(key.score)
+	}
+	b.scoreCounts[key.score]++
+}
+
+func (b *memoryBoard[S]) remove(id string) {
+	key, ok := b.members[id]
+	if !ok {
+		return
+	}
+
+	delete(b.members, id)
+	b.list.Delete(key)
+	b.scoreCounts[key.score]--
+	if b.scoreCounts[key.score] == 0 {
+		delete(b.scoreCounts, key.score)
+		b.scores.Delete(key.score)
+	}
+}
+
+// countScores get number of members whose score is less than score, or equal to it if inclusive.
+func (b *memoryBoard[S]) countScores(score float64, inclusive bool) int {
+	return b.list.CountWhile(func(key memoryKey[S]) bool {
+		return float64(key.score) < score || inclusive && float64(key.score) == score
+	})
+}
+
+// rank get rank of member like scripts of Redis leaderboard.
+func (b *memoryBoard[S]) rank(key memoryKey[S], scheme RankingScheme) int {
+	total := b.list.Len()
+	switch scheme {
+	case RankDense:
+		return b.scores.Len() - b.scores.CountWhile(func(score S) bool {
+			return score <= key.score
+		}) + 1
+	case RankStandardCompetition:
+		return total - b.countScores(float64(key.score), true) + 1
+	case RankModifiedCompetition:
+		return total - b.countScores(float64(key.score), false)
+	default:
+		return total - b.list.Index(key)
+	}
+}
+
+// TypedMemoryLeaderboard defines a leaderboard stored in memory whose member ids have type ID and scores have type S,
+// follows TypedLeaderboard interface. It is safe for concurrent use.
+// Members are kept in an order-statistic skiplist, so members are added and ranked in O(log n).
+// Ids are ordered by their strings encoded by an IDCodec, like in Redis.
+type TypedMemoryLeaderboard[ID comparable, S Score] struct {
+	mu       sync.RWMutex
+	board    *memoryBoard[S]
+	expireAt time.Time
+	codec    IDCodec[ID]
+	opts     *Options
+}
+
+// MemoryLeaderboard defines a leaderboard stored in memory with member ids of any type, follows Leaderboard interface
+type MemoryLeaderboard = TypedMemoryLeaderboard[interface{}, int]
+
+// NewMemoryLeaderBoard create a new leaderboard stored in memory with configs.
+// You can see all supported config in type `Options`, if a config has an unknown value every operation returns an error.
+func NewMemoryLeaderBoard(opts *Options) Leaderboard {
+	return NewTypedMemoryLeaderBoard[interface{}, int](AnyCodec{}, opts)
+}
+
+// NewTypedMemoryLeaderBoard create a new leaderboard stored in memory whose member ids have type ID and are encoded
+// by codec, scores have type S.
+// You can see all supported config in type `Options`, if a config has an unknown value every operation returns an error.
+func NewTypedMemoryLeaderBoard[ID comparable, S Score](codec IDCodec[ID], opts *Options) TypedLeaderboard[ID, S] {
+	if opts == nil {
+		opts = &Options{
+			RankingScheme: RankOrdinal,
+			LifeTime:      1 * time.Hour,
+		}
+	}
+	if err := opts.validate(); err != nil {
+		return &invalidLeaderboard[ID, S]{err: err}
+	}
+
+	return &TypedMemoryLeaderboard[ID, S]{
+		board: newMemoryBoard[S](),
+		codec: codec,
+		opts:  opts,
+	}
+}
+
+// options get configs of leaderboard, so leaderboards built on it like CompositeLeaderboard can check them.
+func (l *TypedMemoryLeaderboard[ID, S]) options() *Options {
+	return l.opts
+}
+
+// view get data of leaderboard to read, it is empty if leaderboard expired.
+// It must be called with read lock.
+func (l *TypedMemoryLeaderboard[ID, S]) view() *memoryBoard[S] {
+	if !l.expireAt.IsZero() && !now().Before(l.expireAt) {
+		return newMemoryBoard[S]()
+	}
+
+	return l.board
+}
+
+// write get data of leaderboard to write, it is cleared if leaderboard expired.
+// It must be called with lock, expiry is extended by Options.LifeTime.
+func (l *TypedMemoryLeaderboard[ID, S]) write() *memoryBoard[S] {
+	l.board = l.view()
+	if l.opts.LifeTime > 0 {
+		l.expireAt = now().Add(l.opts.lifeTime())
+	}
+
+	return l.board
+}
+
+// member get a member from its key.
+func (l *TypedMemoryLeaderboard[ID, S]) member(board *memoryBoard[S], id ID, key memoryKey[S]) *TypedMember[ID, S] {
+	member := &TypedMember[ID, S]{
+		ID:    id,
+		Score: key.score,
+		Rank:  board.rank(key, l.opts.rankingScheme()),
+	}
+
+	if l.opts.IncludeMetadata {
+		if metadata, ok := board.metadata[key.id]; ok {
+			member.Metadata = make(map[string]string, len(metadata))
+			for field, value := range metadata {
+				member.Metadata[field] = value
+			}
+		}
+	}
+
+	return member
+}
+
+// decodeMember get a member from its key, its id is decoded like ids listed from Redis.
+func (l *TypedMemoryLeaderboard[ID, S]) decodeMember(board *memoryBoard[S], key memoryKey[S]) (*TypedMember[ID, S], error) {
+	id, err := l.codec.DecodeID(key.id)
+	if err != nil {
+		return nil, err
+	}
+
+	return l.member(board, id, key), nil
+}
+
+// update apply policy to score of a member, it reports whether the score was changed.
+func (l *TypedMemoryLeaderboard[ID, S]) update(board *memoryBoard[S], memberID string, score S, policy UpdatePolicy) bool {
+	old, ok := board.members[memberID]
+	if ok {
+		switch policy {
+		case UpdateSum:
+			score = old.score + score
+		case UpdateKeepHighest:
+			if score < old.score {
+				score = old.score
+			}
+		case UpdateKeepLowest:
+			if score > old.score {
+				score = old.score
+			}
+		}
+
+		if score == old.score {
+			return false
+		}
+	}
+
+	board.add(memoryKey[S]{score: score, time: keyTieBreakTime(l.opts), id: memberID})
+	return true
+}
+
+func checkScore[S Score](score S) error {
+	if math.IsNaN(float64(score)) {
+		return ErrScoreOutOfRange
+	}
+
+	return nil
+}
+
+// AddMember add a member with score to leaderboard.
+// Score of a member which was already in leaderboard is updated by Options.UpdatePolicy.
+func (l *TypedMemoryLeaderboard[ID, S]) AddMember(ctx context.Context, id ID, score S) error {
+	_, err := l.UpdateMember(ctx, id, score, l.opts.updatePolicy())
+	return err
+}
+
+// AddMemberWithMetadata add a member with score to leaderboard like AddMember, and store metadata of member.
+// Metadata replaces the one stored before, nil metadata keeps it.
+func (l *TypedMemoryLeaderboard[ID, S]) AddMemberWithMetadata(ctx context.Context, id ID, score S, metadata map[string]string) error {
+	return l.AddMembers(ctx, []TypedMember[ID, S]{{ID: id, Score: score, Metadata: metadata}})
+}
+
+// AddMembers add a list of members with their score to leaderboard in one call.
+// It works the same as AddMember for every member, field Rank of members is ignored.
+// Metadata of members is stored like AddMemberWithMetadata if it is not nil.
+func (l *TypedMemoryLeaderboard[ID, S]) AddMembers(ctx context.Context, members []TypedMember[ID, S]) error {
+	if len(members) == 0 {
+		return nil
+	}
+
+	memberIDs := make([]string, 0, len(members))
+	for _, member := range members {
+		memberID, err := l.codec.EncodeID(member.ID)
+		if err != nil {
+			return err
+		}
+
+		if err := checkScore(member.Score); err != nil {
+			return err
+		}
+		memberIDs = append(memberIDs, memberID)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	board := l.write()
+	for idx, member := range members {
+		l.update(board, memberIDs[idx], member.Score, l.opts.updatePolicy())
+		if member.Metadata != nil {
+			metadata := make(map[string]string, len(member.Metadata))
+			for field, value := range member.Metadata {
+				metadata[field] = value
+			}
+			board.metadata[memberIDs[idx]] = metadata
+		}
+	}
+
+	return nil
+}
+
+// UpdateMember add a member with score to leaderboard like AddMember, but update its score by policy instead of
+// Options.UpdatePolicy. It reports whether the stored score was changed, or returns an error if policy is unknown.
+func (l *TypedMemoryLeaderboard[ID, S]) UpdateMember(ctx context.Context, id ID, score S, policy UpdatePolicy) (bool, error) {
+	if err := checkUpdatePolicy(policy); err != nil {
+		return false, err
+	}
+
+	memberID, err := l.codec.EncodeID(id)
+	if err != nil {
+		return false, err
+	}
+
+	if err := checkScore(score); err != nil {
+		return false, err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.update(l.write(), memberID, score, policy), nil
+}
+
+// IncrementScore increase score of a member by delta atomically, a negative delta will decrease it.
+// If member was not in leaderboard, it will be added with score is delta.
+// It returns the member with new score and rank.
+func (l *TypedMemoryLeaderboard[ID, S]) IncrementScore(ctx context.Context, id ID, delta S) (*TypedMember[ID, S], error) {
+	memberID, err := l.codec.EncodeID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkScore(delta); err != nil {
+		return nil, err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	board := l.write()
+	l.update(board, memberID, delta, UpdateSum)
+
+	key := board.members[memberID]
+	return &TypedMember[ID, S]{
+		ID:    id,
+		Score: key.score,
+		Rank:  board.rank(key, l.opts.rankingScheme()),
+	}, nil
+}
+
+// RemoveMember remove members and their metadata from leaderboard by their ids, ids which are not in leaderboard will be ignored.
+func (l *TypedMemoryLeaderboard[ID, S]) RemoveMember(ctx context.Context, ids ...ID) error {
+	memberIDs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		memberID, err := l.codec.EncodeID(id)
+		if err != nil {
+			return err
+		}
+		memberIDs = append(memberIDs, memberID)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	board := l.view()
+	for _, memberID := range memberIDs {
+		board.remove(memberID)
+		delete(board.metadata, memberID)
+	}
+
+	return nil
+}
+
+// listRange get members from index begin to end of list sorted ascending, in order.
+func (l *TypedMemoryLeaderboard[ID, S]) listRange(board *memoryBoard[S], begin, end int, order Order) ([]*TypedMember[ID, S], error) {
+	listMember := make([]*TypedMember[ID, S], 0, maxInt(end-begin, 0))
+	for node := board.list.At(begin); node != nil && len(listMember) < end-begin; node = node.next[0] {
+		member, err := l.decodeMember(board, node.key)
+		if err != nil {
+			return nil, err
+		}
+		listMember = append(listMember, member)
+	}
+
+	if order != OrderAsc {
+		for left, right := 0, len(listMember)-1; left < right; left, right = left+1, right-1 {
+			listMember[left], listMember[right] = listMember[right], listMember[left]
+		}
+	}
+
+	return listMember, nil
+}
+
+// listOffset get limit members from offset in order among members from index first to last of list sorted ascending.
+func (l *TypedMemoryLeaderboard[ID, S]) listOffset(board *memoryBoard[S], first, last, offset, limit int, order Order) ([]*TypedMember[ID, S], Cursor, error) {
+	total := last - first
+	begin := offset
+	end := minInt(offset+limit, total)
+	if order != OrderAsc {
+		begin, end = total-end, total-offset
+	}
+
+	listMember, err := l.listRange(board, first+maxInt(begin, 0), first+end, order)
+	if err != nil {
+		return nil, Cursor{}, err
+	}
+
+	return listMember, Cursor{
+		Begin: offset,
+		End:   offset + len(listMember),
+		Total: total,
+	}, nil
+}
+
+// List get list member with offset, limit and order in leaderboard
+func (l *TypedMemoryLeaderboard[ID, S]) List(ctx context.Context, offset, limit int, order Order) ([]*TypedMember[ID, S], Cursor, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	board := l.view()
+	return l.listOffset(board, 0, board.list.Len(), offset, limit, order)
+}
+
+// ListByScore get list member whose score is in range [min, max] with offset, limit and order in leaderboard.
+// Offset and cursor are counted from the first member in the range.
+func (l *TypedMemoryLeaderboard[ID, S]) ListByScore(ctx context.Context, min, max ScoreBound, offset, limit int, order Order) ([]*TypedMember[ID, S], Cursor, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	board := l.view()
+	first, last, err := l.scoreRange(board, min, max)
+	if err != nil {
+		return nil, Cursor{}, err
+	}
+
+	return l.listOffset(board, first, last, offset, limit, order)
+}
+
+// scoreRange get index of the first member in range [min, max] and of the one after the last member in it.
+func (l *TypedMemoryLeaderboard[ID, S]) scoreRange(board *memoryBoard[S], min, max ScoreBound) (int, int, error) {
+	minScore, minExclusive, err := parseScoreBound(min)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	maxScore, maxExclusive, err := parseScoreBound(max)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	first := board.countScores(minScore, minExclusive)
+	last := board.countScores(maxScore, !maxExclusive)
+	if last < first {
+		last = first
+	}
+
+	return first, last, nil
+}
+
+// GetAround get list member around another member with limit and order.
+// It returns ErrMemberNotFound if member is not in leaderboard.
+func (l *TypedMemoryLeaderboard[ID, S]) GetAround(ctx context.Context, id ID, limit int, order Order) ([]*TypedMember[ID, S], Cursor, error) {
+	memberID, err := l.codec.EncodeID(id)
+	if err != nil {
+		return nil, Cursor{}, err
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	board := l.view()
+	key, ok := board.members[memberID]
+	if !ok {
+		return nil, Cursor{}, ErrMemberNotFound
+	}
+
+	total := board.list.Len()
+	rank := board.list.Index(key)
+	if order != OrderAsc {
+		rank = total - 1 - rank
+	}
+
+	return l.listOffset(board, 0, total, cursorAround(rank, limit, total), limit, order)
+}
+
+// GetRank get rank of a member.
+// It returns ErrMemberNotFound if member is not in leaderboard.
+func (l *TypedMemoryLeaderboard[ID, S]) GetRank(ctx context.Context, id ID) (int, error) {
+	member, err := l.GetMember(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+
+	return member.Rank, nil
+}
+
+// GetPercentile get percentile of a member in leaderboard, it is in range (0, 100] and smaller is better.
+// With RankDense it is the rank of member over number of distinct scores, otherwise it is the rank over number of members.
+// It returns ErrMemberNotFound if member is not in leaderboard.
+func (l *TypedMemoryLeaderboard[ID, S]) GetPercentile(ctx context.Context, id ID) (float64, error) {
+	memberID, err := l.codec.EncodeID(id)
+	if err != nil {
+		return 0, err
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	board := l.view()
+	key, ok := board.members[memberID]
+	if !ok {
+		return 0, ErrMemberNotFound
+	}
+
+	total := board.list.Len()
+	if l.opts.rankingScheme() == RankDense {
+		total = board.scores.Len()
+	}
+
+	return percentile(board.rank(key, l.opts.rankingScheme()), total), nil
+}
+
+// GetMember get score and rank of a member in one call.
+// It returns ErrMemberNotFound if member is not in leaderboard.
+func (l *TypedMemoryLeaderboard[ID, S]) GetMember(ctx context.Context, id ID) (*TypedMember[ID, S], error) {
+	members, err := l.GetMembers(ctx, []ID{id})
+	if err != nil {
+		return nil, err
+	}
+
+	if members[0] == nil {
+		return nil, ErrMemberNotFound
+	}
+
+	return members[0], nil
+}
+
+// GetMembers get score and rank of a list of members in one call.
+// Members are returned in the same order as ids, a member is nil if its id is not in leaderboard.
+func (l *TypedMemoryLeaderboard[ID, S]) GetMembers(ctx context.Context, ids []ID) ([]*TypedMember[ID, S], error) {
+	memberIDs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		memberID, err := l.codec.EncodeID(id)
+		if err != nil {
+			return nil, err
+		}
+		memberIDs = append(memberIDs, memberID)
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	board := l.view()
+	listMember := make([]*TypedMember[ID, S], len(ids))
+	for idx, id := range ids {
+		if key, ok := board.members[memberIDs[idx]]; ok {
+			listMember[idx] = l.member(board, id, key)
+		}
+	}
+
+	return listMember, nil
+}
+
+// listSubset get members of ids which are in leaderboard with their rank among them, ordered by rank.
+// It must be called with read lock.
+func (l *TypedMemoryLeaderboard[ID, S]) listSubset(board *memoryBoard[S], ids []ID) ([]*TypedMember[ID, S], error) {
+	keys := make([]memoryKey[S], 0, len(ids))
+	found := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		memberID, err := l.codec.EncodeID(id)
+		if err != nil {
+			return nil, err
+		}
+
+		if key, ok := board.members[memberID]; ok && !found[memberID] {
+			found[memberID] = true
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return lessMemoryKey(keys[j], keys[i])
+	})
+
+	listMember := make([]*TypedMember[ID, S], 0, len(keys))
+	for _, key := range keys {
+		member, err := l.decodeMember(board, key)
+		if err != nil {
+			return nil, err
+		}
+		listMember = append(listMember, member)
+	}
+
+	rankSubset(listMember, l.opts.rankingScheme())
+	return listMember, nil
+}
+
+// ListSubset get members of ids with order, for example to rank a member among its friends.
+// Members are ranked among ids like Options.RankingScheme ranks them in leaderboard, Member.SubsetRank is
+// their rank among ids and Member.Rank is their rank in leaderboard. Ids which are not in leaderboard are ignored.
+func (l *TypedMemoryLeaderboard[ID, S]) ListSubset(ctx context.Context, ids []ID, order Order) ([]*TypedMember[ID, S], error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	listMember, err := l.listSubset(l.view(), ids)
+	if err != nil {
+		return nil, err
+	}
+
+	if order == OrderAsc {
+		for left, right := 0, len(listMember)-1; left < right; left, right = left+1, right-1 {
+			listMember[left], listMember[right] = listMember[right], listMember[left]
+		}
+	}
+
+	return listMember, nil
+}
+
+// GetRankInSubset get rank of a member among itself and members of ids like ListSubset.
+// It returns ErrMemberNotFound if member is not in leaderboard.
+func (l *TypedMemoryLeaderboard[ID, S]) GetRankInSubset(ctx context.Context, id ID, ids []ID) (int, error) {
+	memberID, err := l.codec.EncodeID(id)
+	if err != nil {
+		return 0, err
+	}
+
+	subset := make([]ID, 0, len(ids)+1)
+	subset = append(subset, id)
+	subset = append(subset, ids...)
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	listMember, err := l.listSubset(l.view(), subset)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, member := range listMember {
+		if encoded, _ := l.codec.EncodeID(member.ID); encoded == memberID {
+			return member.SubsetRank, nil
+		}
+	}
+
+	return 0, ErrMemberNotFound
+}
+
+// Count get number of members in leaderboard
+func (l *TypedMemoryLeaderboard[ID, S]) Count(ctx context.Context) (int, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return l.view().list.Len(), nil
+}
+
+// CountByScore get number of members whose score is in range [min, max]
+func (l *TypedMemoryLeaderboard[ID, S]) CountByScore(ctx context.Context, min, max ScoreBound) (int, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	first, last, err := l.scoreRange(l.view(), min, max)
+	return last - first, err
+}
+
+// Clean clear all data of leaderboard
+func (l *TypedMemoryLeaderboard[ID, S]) Clean(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.board = newMemoryBoard[S]()
+	l.expireAt = time.Time{}
+	return nil
+}
+
+// parseScoreBound get score of a bound and whether it is exclusive.
+func parseScoreBound(bound ScoreBound) (float64, bool, error) {
+	switch bound {
+	case MinScore:
+		return math.Inf(-1), false, nil
+	case MaxScore:
+		return math.Inf(1), false, nil
+	}
+
+	exclusive := strings.HasPrefix(string(bound), "(")
+	score, err := strconv.ParseFloat(strings.TrimPrefix(string(bound), "("), 64)
+	return score, exclusive, err
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,195 @@
+package goleaderboard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSkiplist(t *testing.T) {
+	list := newSkiplist(func(a, b int) bool {
+		return a < b
+	})
+
+	keys := rand.Perm(1000)
+	for _, key := range keys {
+		list.Insert(key)
+	}
+
+	for _, key := range keys[:500] {
+		if !list.Delete(key) {
+			t.Fatalf("Error in delete key %v\nExpected: deleted\nReceived: not found", key)
+		}
+	}
+
+	remain := append([]int{}, keys[500:]...)
+	sort.Ints(remain)
+	if list.Len() != len(remain) {
+		t.Fatalf("Error in length of skiplist\nExpected: %v\nReceived: %v", len(remain), list.Len())
+	}
+
+	for idx, key := range remain {
+		if index := list.Index(key); index != idx {
+			t.Errorf("Error in index of key %v\nExpected: %v\nReceived: %v", key, idx, index)
+		}
+
+		if node := list.At(idx); node == nil || node.key != key {
+			t.Errorf("Error in key at index %v\nExpected: %v\nReceived: %+v", idx, key, node)
+		}
+	}
+}
+
+func TestMemoryLeaderboard(t *testing.T) {
+	testCases := []struct {
+		scheme RankingScheme
+		ranks  []int
+	}{
+		{
+			scheme: RankOrdinal,
+			ranks:  []int{1, 2, 3, 4, 5},
+		},
+		{
+			scheme: RankDense,
+			ranks:  []int{1, 2, 2, 3, 4},
+		},
+		{
+			scheme: RankStandardCompetition,
+			ranks:  []int{1, 2, 2, 4, 5},
+		},
+		{
+			scheme: RankModifiedCompetition,
+			ranks:  []int{1, 3, 3, 4, 5},
+		},
+	}
+
+	for _, tc := range testCases {
+		ctx := context.Background()
+		leaderboard := NewMemoryLeaderBoard(&Options{RankingScheme: tc.scheme})
+
+		for idx, score := range []int{50, 40, 40, 30, 20} {
+			addMember(t, ctx, leaderboard, fmt.Sprintf("P%v", idx), score)
+		}
+
+		members, cursor, err := leaderboard.List(ctx, 0, 10, OrderDesc)
+		if err != nil {
+			t.Error("failed to list members", err.Error())
+			continue
+		}
+
+		if cursor.Total != 5 || len(members) != 5 {
+			t.Errorf("Error in list member with %v\nExpected: 5 members\nReceived: %+v, %+v", tc.scheme, members, cursor)
+			continue
+		}
+
+		// members with the same score are ordered by id like in Redis
+		for idx, id := range []string{"P0", "P2", "P1", "P3", "P4"} {
+			if members[idx].ID != id || members[idx].Rank != tc.ranks[idx] {
+				t.Errorf("Error in list member with %v\nExpected: %v at rank #%v\nReceived: %+v", tc.scheme, id, tc.ranks[idx], members[idx])
+			}
+		}
+
+		members, cursor, err = leaderboard.List(ctx, 1, 2, OrderAsc)
+		if err != nil || len(members) != 2 || members[0].ID != "P3" || members[1].ID != "P1" || cursor.End != 3 {
+			t.Errorf("Error in list member asc with %v\nExpected: P3, P1\nReceived: %+v, %+v, %v", tc.scheme, members, cursor, err)
+		}
+
+		members, cursor, err = leaderboard.ListByScore(ctx, Inclusive(30), Exclusive(50), 0, 10, OrderDesc)
+		if err != nil || len(members) != 3 || cursor.Total != 3 || members[2].ID != "P3" {
+			t.Errorf("Error in list member by score with %v\nExpected: P2, P1, P3\nReceived: %+v, %+v, %v", tc.scheme, members, cursor, err)
+		}
+
+		// the first member can not be in the middle
+		members, cursor, err = leaderboard.GetAround(ctx, "P0", 3, OrderDesc)
+		if err != nil || len(members) != 3 || members[0].ID != "P0" || cursor.Begin != 0 || cursor.End != 3 {
+			t.Errorf("Error in get around first member with %v\nExpected: P0, P2, P1\nReceived: %+v, %+v, %v", tc.scheme, members, cursor, err)
+		}
+
+		members, cursor, err = leaderboard.GetAround(ctx, "P4", 2, OrderDesc)
+		if err != nil || len(members) != 2 || members[1].ID != "P4" || cursor.Begin != 3 {
+			t.Errorf("Error in get around last member with %v\nExpected: P3, P4\nReceived: %+v, %+v, %v", tc.scheme, members, cursor, err)
+		}
+
+		member, err := leaderboard.IncrementScore(ctx, "P4", 25)
+		if err != nil || member.Score != 45 || member.Rank != 2 {
+			t.Errorf("Error in increment score with %v\nExpected: score 45 at rank #2\nReceived: %+v, %v", tc.scheme, member, err)
+		}
+
+		if err := leaderboard.RemoveMember(ctx, "P0"); err != nil {
+			t.Error("failed to remove member", err.Error())
+			continue
+		}
+		getRank(t, ctx, leaderboard, "P4", 1)
+
+		if _, err := leaderboard.GetRank(ctx, "P0"); !errors.Is(err, ErrMemberNotFound) {
+			t.Errorf("Error in get rank of removed member with %v\nExpected: %v\nReceived: %v", tc.scheme, ErrMemberNotFound, err)
+		}
+	}
+}
+
+func TestMemoryLeaderboardLifeTime(t *testing.T) {
+	defer func() {
+		now = time.Now
+	}()
+
+	ctx := context.Background()
+	clock := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	setClock(t, clock)
+
+	leaderboard := NewMemoryLeaderBoard(&Options{LifeTime: time.Hour})
+	addMember(t, ctx, leaderboard, "P1", 10)
+
+	setClock(t, clock.Add(30*time.Minute))
+	addMember(t, ctx, leaderboard, "P2", 20)
+
+	// expiry is extended by the last write
+	setClock(t, clock.Add(80*time.Minute))
+	getRank(t, ctx, leaderboard, "P1", 2)
+
+	setClock(t, clock.Add(90*time.Minute))
+	if total, err := leaderboard.Count(ctx); err != nil || total != 0 {
+		t.Errorf("Error in count expired leaderboard\nExpected: 0\nReceived: %v, %v", total, err)
+	}
+
+	addMember(t, ctx, leaderboard, "P3", 5)
+	getRank(t, ctx, leaderboard, "P3", 1)
+}
+
+func TestMemoryLeaderboardConcurrency(t *testing.T) {
+	ctx := context.Background()
+	leaderboard := NewMemoryLeaderBoard(&Options{UpdatePolicy: UpdateSum})
+
+	var wg sync.WaitGroup
+	for worker := 0; worker < 8; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := 0; idx < 100; idx++ {
+				if err := leaderboard.AddMember(ctx, fmt.Sprintf("P%v", idx%10), 1); err != nil {
+					t.Error("failed to add member", err.Error())
+					return
+				}
+				if _, _, err := leaderboard.List(ctx, 0, 5, OrderDesc); err != nil {
+					t.Error("failed to list members", err.Error())
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	members, _, err := leaderboard.List(ctx, 0, 10, OrderDesc)
+	if err != nil {
+		t.Fatal("failed to list members", err.Error())
+	}
+
+	for _, member := range members {
+		if member.Score != 80 {
+			t.Errorf("Error in concurrent sum of scores\nExpected: 80\nReceived: %+v", member)
+		}
+	}
+}
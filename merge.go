@@ -0,0 +1,236 @@
+package goleaderboard
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Aggregate is the way to combine scores of a member in several leaderboards.
+type Aggregate string
+
+var (
+	AggregateSum Aggregate = "SUM"
+	AggregateMin Aggregate = "MIN"
+	AggregateMax Aggregate = "MAX"
+)
+
+// aggregateOf get the aggregate which combines scores like policy updates them, UpdateSum is AggregateSum,
+// UpdateKeepHighest is AggregateMax and UpdateKeepLowest is AggregateMin.
+func aggregateOf(policy UpdatePolicy) Aggregate {
+	switch policy {
+	case UpdateKeepHighest:
+		return AggregateMax
+	case UpdateKeepLowest:
+		return AggregateMin
+	default:
+		return AggregateSum
+	}
+}
+
+// mergeBoards store the union or intersection of boards to leaderboard dest, it is kept for cacheTime.
+// The merge is skipped if dest was merged less than cacheTime ago, unless force is set.
+// With same rank, members are merged to member score set of dest and distinct scores are rebuilt in rank set.
+// With Options.IncludeMetadata, metadata of a member is copied to dest from the first board which has it.
+func mergeBoards(
+	ctx context.Context,
+	redisClient redis.UniversalClient,
+	mergeScript *redis.Script,
+	dest string,
+	intersect bool,
+	aggregate Aggregate,
+	cacheTime time.Duration,
+	force bool,
+	opts *Options,
+	boards []string,
+	weights []float64,
+) error {
+	command := "ZUNIONSTORE"
+	if intersect {
+		command = "ZINTERSTORE"
+	}
+
+	sameRank := opts.rankingScheme() != RankOrdinal
+	memberSetName := generateRankSetName
+	if sameRank {
+		memberSetName = generateMemScoreSetName
+	}
+
+	keys := make([]string, 0, len(boards)+2)
+	keys = append(keys, memberSetName(opts.keyName(dest)), generateRankSetName(opts.keyName(dest)))
+	for _, board := range boards {
+		keys = append(keys, memberSetName(opts.keyName(board)))
+	}
+
+	if opts.IncludeMetadata {
+		keys = append(keys, generateMetadataHashName(opts.keyName(dest)))
+		for _, board := range boards {
+			keys = append(keys, generateMetadataHashName(opts.keyName(board)))
+		}
+	}
+
+	args := make([]interface{}, 0, len(weights)+5)
+	args = append(args, command, string(aggregate), cacheTime.Milliseconds(), force, sameRank)
+	for _, weight := range weights {
+		args = append(args, weight)
+	}
+
+	return mergeScript.Run(ctx, redisClient, keys, args...).Err()
+}
+
+// mergedReader read a leaderboard merged from other boards on read, like an aggregate or a rolling window leaderboard.
+// merged get the merged board, it merges boards again if they are not cached anymore.
+type mergedReader[ID comparable, S Score] struct {
+	merged func(ctx context.Context) (TypedLeaderboard[ID, S], error)
+}
+
+// List get list member with offset, limit and order in the merged board
+func (r mergedReader[ID, S]) List(ctx context.Context, offset, limit int, order Order) ([]*TypedMember[ID, S], Cursor, error) {
+	leaderboard, err := r.merged(ctx)
+	if err != nil {
+		return nil, Cursor{}, err
+	}
+
+	return leaderboard.List(ctx, offset, limit, order)
+}
+
+// ListByScore get list member whose merged score is in range [min, max] with offset, limit and order.
+func (r mergedReader[ID, S]) ListByScore(ctx context.Context, min, max ScoreBound, offset, limit int, order Order) ([]*TypedMember[ID, S], Cursor, error) {
+	leaderboard, err := r.merged(ctx)
+	if err != nil {
+		return nil, Cursor{}, err
+	}
+
+	return leaderboard.ListByScore(ctx, min, max, offset, limit, order)
+}
+
+// GetAround get list member around another member in the merged board with limit and order
+func (r mergedReader[ID, S]) GetAround(ctx context.Context, id ID, limit int, order Order) ([]*TypedMember[ID, S], Cursor, error) {
+	leaderboard, err := r.merged(ctx)
+	if err != nil {
+		return nil, Cursor{}, err
+	}
+
+	return leaderboard.GetAround(ctx, id, limit, order)
+}
+
+// GetRank get rank of a member in the merged board.
+// It returns ErrMemberNotFound if member is not in the merged board.
+func (r mergedReader[ID, S]) GetRank(ctx context.Context, id ID) (int, error) {
+	leaderboard, err := r.merged(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return leaderboard.GetRank(ctx, id)
+}
+
+// GetPercentile get percentile of a member in the merged board.
+func (r mergedReader[ID, S]) GetPercentile(ctx context.Context, id ID) (float64, error) {
+	leaderboard, err := r.merged(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return leaderboard.GetPercentile(ctx, id)
+}
+
+// GetMember get merged score and rank of a member in one call.
+func (r mergedReader[ID, S]) GetMember(ctx context.Context, id ID) (*TypedMember[ID, S], error) {
+	leaderboard, err := r.merged(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return leaderboard.GetMember(ctx, id)
+}
+
+// GetMembers get merged score and rank of a list of members in one call.
+func (r mergedReader[ID, S]) GetMembers(ctx context.Context, ids []ID) ([]*TypedMember[ID, S], error) {
+	leaderboard, err := r.merged(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return leaderboard.GetMembers(ctx, ids)
+}
+
+// Count get number of members in the merged board
+func (r mergedReader[ID, S]) Count(ctx context.Context) (int, error) {
+	leaderboard, err := r.merged(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return leaderboard.Count(ctx)
+}
+
+func initMergeScript() string {
+	return `
+local member_set = KEYS[1]
+local rank_set = KEYS[2]
+local command = ARGV[1]
+local aggregate = ARGV[2]
+local cache_time = tonumber(ARGV[3])
+local force = ARGV[4] == "1"
+local same_rank = ARGV[5] == "1"
+local boards = #ARGV - 5
+
+-- metadata of dest and of boards are the last keys when metadata is included
+local include_metadata = #KEYS > boards + 2
+
+if not force and cache_time > 0 and redis.call("PTTL", member_set) > 0 then
+	return 0
+end
+
+-- member set of boards are the next keys, their weights are the next args
+local args = {command, member_set, boards}
+for idx = 3, boards + 2 do
+	table.insert(args, KEYS[idx])
+end
+
+table.insert(args, "WEIGHTS")
+for idx = 6, #ARGV do
+	table.insert(args, ARGV[idx])
+end
+table.insert(args, "AGGREGATE")
+table.insert(args, aggregate)
+
+redis.call("DEL", member_set, rank_set)
+redis.call(unpack(args))
+
+if same_rank then
+	local scores = redis.call("ZRANGE", member_set, 0, -1, "WITHSCORES")
+	for idx = 2, #scores, 2 do
+		redis.call("ZADD", rank_set, scores[idx], scores[idx])
+	end
+end
+
+if include_metadata then
+	local metadata_hash = KEYS[boards + 3]
+	redis.call("DEL", metadata_hash)
+
+	-- boards are copied from the last one, so metadata of the first board which has a member is kept
+	for idx = 2 * boards + 3, boards + 4, -1 do
+		local metadata = redis.call("HGETALL", KEYS[idx])
+		for field = 1, #metadata, 2 do
+			if redis.call("ZSCORE", member_set, metadata[field]) then
+				redis.call("HSET", metadata_hash, metadata[field], metadata[field + 1])
+			end
+		end
+	end
+
+	if cache_time > 0 then
+		redis.call("PEXPIRE", metadata_hash, cache_time)
+	end
+end
+
+if cache_time > 0 then
+	redis.call("PEXPIRE", member_set, cache_time)
+	redis.call("PEXPIRE", rank_set, cache_time)
+end
+
+return 1
+`
+}
@@ -0,0 +1,88 @@
+package goleaderboard
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// AddMemberWithMetadata add a member with score to leaderboard like AddMember, and store metadata of member,
+// for example its name or avatar. Metadata replaces the one stored before, nil metadata keeps it.
+// Score and metadata are written in one script, so a member is not left without its metadata.
+func (l *TypedRedisLeaderboard[ID, S]) AddMemberWithMetadata(ctx context.Context, id ID, score S, metadata map[string]string) error {
+	return l.AddMembers(ctx, []TypedMember[ID, S]{{ID: id, Score: score, Metadata: metadata}})
+}
+
+// encodeMemberMetadata encode metadata of members to be stored with their scores by scripts, it is empty for members
+// without metadata so their stored metadata is kept. It also reports whether any member has metadata.
+func (l *TypedRedisLeaderboard[ID, S]) encodeMemberMetadata(members []TypedMember[ID, S]) ([]string, bool, error) {
+	values := make([]string, len(members))
+	hasMetadata := false
+	for idx, member := range members {
+		if member.Metadata == nil {
+			continue
+		}
+
+		value, err := json.Marshal(member.Metadata)
+		if err != nil {
+			return nil, false, err
+		}
+		values[idx] = string(value)
+		hasMetadata = true
+	}
+
+	return values, hasMetadata, nil
+}
+
+// attachMetadata get metadata of members from metadata hash if Options.IncludeMetadata is set, nil members are ignored.
+func (l *TypedRedisLeaderboard[ID, S]) attachMetadata(ctx context.Context, members ...*TypedMember[ID, S]) error {
+	if !l.opts.IncludeMetadata {
+		return nil
+	}
+
+	fields := make([]string, 0, len(members))
+	listMember := make([]*TypedMember[ID, S], 0, len(members))
+	for _, member := range members {
+		if member == nil {
+			continue
+		}
+
+		memberID, err := l.codec.EncodeID(member.ID)
+		if err != nil {
+			return err
+		}
+		fields = append(fields, memberID)
+		listMember = append(listMember, member)
+	}
+
+	if len(fields) == 0 {
+		return nil
+	}
+
+	values, err := l.redisClient.HMGet(ctx, l.metadataHash, fields...).Result()
+	if err != nil {
+		return err
+	}
+
+	for idx, member := range listMember {
+		if values[idx] == nil {
+			continue
+		}
+
+		if err := json.Unmarshal([]byte(values[idx].(string)), &member.Metadata); err != nil {
+			return fmt.Errorf("goleaderboard: invalid metadata of member %q: %w", fields[idx], err)
+		}
+	}
+
+	return nil
+}
+
+func initSetMetadataFunctionScript() string {
+	return `
+local function set_metadata(metadata, member_id, value)
+	if value ~= "" then
+		redis.call("HSET", metadata, member_id, value)
+	end
+end
+`
+}
@@ -0,0 +1,169 @@
+package goleaderboard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestMetadata(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	testCases := []struct {
+		allowSameRank bool
+	}{
+		{
+			allowSameRank: false,
+		},
+		{
+			allowSameRank: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		ctx := context.Background()
+		leaderboard := NewLeaderBoard(redisClient, "test", &Options{
+			AllowSameRank:   tc.allowSameRank,
+			IncludeMetadata: true,
+			LifeTime:        time.Hour,
+		})
+		defer clean(t, ctx, leaderboard)
+
+		err := leaderboard.AddMemberWithMetadata(ctx, "P1", 20, map[string]string{"name": "Alice", "country": "VN"})
+		if err != nil {
+			t.Error("failed to add member with metadata", err.Error())
+			return
+		}
+
+		err = leaderboard.AddMembers(ctx, []Member{
+			{ID: "P2", Score: 10, Metadata: map[string]string{"name": "Bob"}},
+			{ID: "P3", Score: 5},
+		})
+		if err != nil {
+			t.Error("failed to add members", err.Error())
+			return
+		}
+
+		members, _, err := leaderboard.List(ctx, 0, 3, OrderDesc)
+		if err != nil {
+			t.Error("failed to list members", err.Error())
+			return
+		}
+
+		if members[0].Metadata["name"] != "Alice" || members[0].Metadata["country"] != "VN" || members[1].Metadata["name"] != "Bob" || members[2].Metadata != nil {
+			t.Errorf("Error in list member with metadata, same rank %v\nExpected: Alice from VN, Bob and no metadata\nReceived: %v, %v, %v", tc.allowSameRank, members[0].Metadata, members[1].Metadata, members[2].Metadata)
+		}
+
+		members, _, err = leaderboard.GetAround(ctx, "P2", 1, OrderDesc)
+		if err != nil {
+			t.Error("failed to get around member", err.Error())
+			return
+		}
+
+		if len(members) != 1 || members[0].Metadata["name"] != "Bob" {
+			t.Errorf("Error in get around member with metadata, same rank %v\nExpected: Bob\nReceived: %+v", tc.allowSameRank, members)
+		}
+
+		ttl, err := redisClient.TTL(ctx, generateMetadataHashName("test")).Result()
+		if err != nil || ttl <= 0 {
+			t.Errorf("Error in lifetime of metadata, same rank %v\nExpected: positive ttl\nReceived: %v, %v", tc.allowSameRank, ttl, err)
+		}
+
+		if err := leaderboard.RemoveMember(ctx, "P1"); err != nil {
+			t.Error("failed to remove member", err.Error())
+			return
+		}
+
+		exists, err := redisClient.HExists(ctx, generateMetadataHashName("test"), "P1").Result()
+		if err != nil || exists {
+			t.Errorf("Error in remove metadata of member, same rank %v\nExpected: removed\nReceived: %v, %v", tc.allowSameRank, exists, err)
+		}
+
+		if err := leaderboard.AddMember(ctx, "P1", 30); err != nil {
+			t.Error("failed to add member", err.Error())
+			return
+		}
+
+		member, err := leaderboard.GetMember(ctx, "P1")
+		if err != nil {
+			t.Error("failed to get member", err.Error())
+			return
+		}
+
+		if member.Metadata != nil {
+			t.Errorf("Error in get member without metadata, same rank %v\nExpected: nil\nReceived: %v", tc.allowSameRank, member.Metadata)
+		}
+		clean(t, ctx, leaderboard)
+
+		count, err := redisClient.Exists(ctx, generateMetadataHashName("test")).Result()
+		if err != nil || count != 0 {
+			t.Errorf("Error in clean metadata, same rank %v\nExpected: deleted\nReceived: %v, %v", tc.allowSameRank, count, err)
+		}
+	}
+}
+
+func TestMetadataWithScore(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	ctx := context.Background()
+	leaderboard := NewLeaderBoard(redisClient, "test", &Options{
+		TieBreak:        TieBreakEarliest,
+		UpdatePolicy:    UpdateSum,
+		IncludeMetadata: true,
+	})
+	defer clean(t, ctx, leaderboard)
+
+	if err := leaderboard.AddMemberWithMetadata(ctx, "P1", MaxTieBreakScore, map[string]string{"name": "Alice"}); err != nil {
+		t.Fatal("failed to add member with metadata", err.Error())
+	}
+
+	// the sum is out of range, so metadata is not written without the score
+	err := leaderboard.AddMemberWithMetadata(ctx, "P1", 1, map[string]string{"name": "Bob"})
+	if !errors.Is(err, ErrScoreOutOfRange) {
+		t.Errorf("Error in add member with metadata and huge score\nExpected: %v\nReceived: %v", ErrScoreOutOfRange, err)
+	}
+
+	// nil metadata keeps the stored one
+	if err := leaderboard.AddMemberWithMetadata(ctx, "P1", -1, nil); err != nil {
+		t.Fatal("failed to add member without metadata", err.Error())
+	}
+
+	member, err := leaderboard.GetMember(ctx, "P1")
+	if err != nil || member.Score != MaxTieBreakScore-1 || member.Metadata["name"] != "Alice" {
+		t.Errorf("Error in get member with metadata\nExpected: score %v with name Alice\nReceived: %+v, %v", MaxTieBreakScore-1, member, err)
+	}
+}
+
+func TestRemoveManyMembersWithMetadata(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	ctx := context.Background()
+	leaderboard := NewLeaderBoard(redisClient, "test", &Options{RankingScheme: RankDense, IncludeMetadata: true})
+	defer clean(t, ctx, leaderboard)
+
+	// ids are deleted in several chunks
+	members := make([]Member, 2500)
+	ids := make([]interface{}, len(members))
+	for idx := range members {
+		ids[idx] = fmt.Sprintf("P%v", idx)
+		members[idx] = Member{ID: ids[idx], Score: idx % 10, Metadata: map[string]string{"name": "Alice"}}
+	}
+
+	if err := leaderboard.AddMembers(ctx, members); err != nil {
+		t.Fatal("failed to add members", err.Error())
+	}
+
+	if err := leaderboard.RemoveMember(ctx, ids...); err != nil {
+		t.Fatal("failed to remove members", err.Error())
+	}
+
+	count, err := redisClient.Exists(ctx, generateMetadataHashName("test"), generateRankSetName("test")).Result()
+	if err != nil || count != 0 {
+		t.Errorf("Error in remove many members with metadata\nExpected: no keys\nReceived: %v, %v", count, err)
+	}
+}
@@ -0,0 +1,159 @@
+package goleaderboard
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Period is the length of time a periodic leaderboard keeps scores before it starts a new board.
+type Period string
+
+var (
+	// PeriodDaily starts a new board at midnight.
+	PeriodDaily Period = "daily"
+	// PeriodWeekly starts a new board at midnight of Monday, periods are named by ISO week.
+	PeriodWeekly Period = "weekly"
+	// PeriodMonthly starts a new board at midnight of the first day of month.
+	PeriodMonthly Period = "monthly"
+)
+
+// PeriodicOptions contains all configs for periodic leaderboard
+type PeriodicOptions struct {
+	// Period is the length of periods, default is PeriodDaily.
+	Period Period
+	// Location is the timezone periods start in, default is UTC.
+	Location *time.Location
+	// Retention is how long a board is kept after its period ends, 0 means boards never expire.
+	Retention time.Duration
+	// Clock get the current time to choose the current period, default is time.Now.
+	Clock func() time.Time
+	// Options is configs of board of every period, its LifeTime is replaced by Retention.
+	Options Options
+}
+
+// validate return an error if Period or a config of Options has an unknown value.
+func (o *PeriodicOptions) validate() error {
+	switch o.Period {
+	case "", PeriodDaily, PeriodWeekly, PeriodMonthly:
+	default:
+		return fmt.Errorf("goleaderboard: unknown period %q", o.Period)
+	}
+
+	return o.Options.validate()
+}
+
+// TypedPeriodicLeaderboard defines leaderboards stored in Redis which are rotated every period,
+// every period has its own board named by the name of leaderboard and the start of period.
+type TypedPeriodicLeaderboard[ID comparable, S Score] struct {
+	redisClient redis.UniversalClient
+	name        string
+	codec       IDCodec[ID]
+	opts        *PeriodicOptions
+}
+
+// PeriodicLeaderboard defines periodic leaderboards with member ids of any type.
+type PeriodicLeaderboard = TypedPeriodicLeaderboard[interface{}, int]
+
+// NewPeriodicLeaderBoard create a new periodic leaderboard stored in Redis with specific name and configs.
+func NewPeriodicLeaderBoard(redisClient redis.UniversalClient, name string, opts *PeriodicOptions) (*PeriodicLeaderboard, error) {
+	return NewTypedPeriodicLeaderBoard[interface{}, int](redisClient, name, AnyCodec{}, opts)
+}
+
+// NewTypedPeriodicLeaderBoard create a new periodic leaderboard stored in Redis whose member ids have type ID
+// and are encoded by codec, scores have type S. It returns an error if Period or a config of Options has an unknown value.
+func NewTypedPeriodicLeaderBoard[ID comparable, S Score](redisClient redis.UniversalClient, name string, codec IDCodec[ID], opts *PeriodicOptions) (*TypedPeriodicLeaderboard[ID, S], error) {
+	if opts == nil {
+		opts = &PeriodicOptions{}
+	}
+
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
+	return &TypedPeriodicLeaderboard[ID, S]{
+		redisClient: redisClient,
+		name:        name,
+		codec:       codec,
+		opts:        opts,
+	}, nil
+}
+
+func (p *TypedPeriodicLeaderboard[ID, S]) now() time.Time {
+	if p.opts.Clock != nil {
+		return p.opts.Clock()
+	}
+
+	return now()
+}
+
+func (p *TypedPeriodicLeaderboard[ID, S]) location() *time.Location {
+	if p.opts.Location != nil {
+		return p.opts.Location
+	}
+
+	return time.UTC
+}
+
+// PeriodStart get the start of period which contains t.
+func (p *TypedPeriodicLeaderboard[ID, S]) PeriodStart(t time.Time) time.Time {
+	t = t.In(p.location())
+	year, month, day := t.Date()
+	switch p.opts.Period {
+	case PeriodWeekly:
+		// weeks start on Monday
+		return time.Date(year, month, day-(int(t.Weekday())+6)%7, 0, 0, 0, 0, t.Location())
+	case PeriodMonthly:
+		return time.Date(year, month, 1, 0, 0, 0, 0, t.Location())
+	default:
+		return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+	}
+}
+
+// PeriodEnd get the end of period which contains t, it is the start of the next period.
+func (p *TypedPeriodicLeaderboard[ID, S]) PeriodEnd(t time.Time) time.Time {
+	start := p.PeriodStart(t)
+	switch p.opts.Period {
+	case PeriodWeekly:
+		return start.AddDate(0, 0, 7)
+	case PeriodMonthly:
+		return start.AddDate(0, 1, 0)
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
+
+// Name get name of board of period which contains t, for example "kills:2026-10-16", "kills:2026-W42" or "kills:2026-10".
+func (p *TypedPeriodicLeaderboard[ID, S]) Name(t time.Time) string {
+	start := p.PeriodStart(t)
+	switch p.opts.Period {
+	case PeriodWeekly:
+		year, week := start.ISOWeek()
+		return fmt.Sprintf("%s:%04d-W%02d", p.name, year, week)
+	case PeriodMonthly:
+		return fmt.Sprintf("%s:%s", p.name, start.Format("2006-01"))
+	default:
+		return fmt.Sprintf("%s:%s", p.name, start.Format("2006-01-02"))
+	}
+}
+
+// Current get board of the current period, members should be added to it.
+func (p *TypedPeriodicLeaderboard[ID, S]) Current() TypedLeaderboard[ID, S] {
+	return p.At(p.now())
+}
+
+// At get board of period which contains t, for example to read a past period.
+func (p *TypedPeriodicLeaderboard[ID, S]) At(t time.Time) TypedLeaderboard[ID, S] {
+	opts := p.opts.Options
+	opts.LifeTime = 0
+	if p.opts.Retention > 0 {
+		// board is written only in its period, so it expires Retention after the end of period
+		opts.LifeTime = p.PeriodEnd(t).Add(p.opts.Retention).Sub(p.now())
+		if opts.LifeTime < time.Second {
+			opts.LifeTime = time.Second
+		}
+	}
+
+	return NewTypedLeaderBoard[ID, S](p.redisClient, p.Name(t), p.codec, &opts)
+}
@@ -0,0 +1,101 @@
+package goleaderboard
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPeriodicName(t *testing.T) {
+	ict := time.FixedZone("ICT", 7*60*60)
+	// it is already Saturday 2026-10-17 in ICT
+	clock := time.Date(2026, 10, 16, 23, 30, 0, 0, time.UTC)
+
+	testCases := []struct {
+		period Period
+		name   string
+		end    time.Time
+	}{
+		{
+			period: PeriodDaily,
+			name:   "kills:2026-10-17",
+			end:    time.Date(2026, 10, 18, 0, 0, 0, 0, ict),
+		},
+		{
+			period: PeriodWeekly,
+			name:   "kills:2026-W42",
+			end:    time.Date(2026, 10, 19, 0, 0, 0, 0, ict),
+		},
+		{
+			period: PeriodMonthly,
+			name:   "kills:2026-10",
+			end:    time.Date(2026, 11, 1, 0, 0, 0, 0, ict),
+		},
+	}
+
+	for _, tc := range testCases {
+		leaderboard, err := NewPeriodicLeaderBoard(nil, "kills", &PeriodicOptions{Period: tc.period, Location: ict})
+		if err != nil {
+			t.Fatal("failed to create periodic leaderboard", err.Error())
+		}
+
+		if name := leaderboard.Name(clock); name != tc.name {
+			t.Errorf("Error in name of period %v\nExpected: %v\nReceived: %v", tc.period, tc.name, name)
+		}
+
+		if end := leaderboard.PeriodEnd(clock); !end.Equal(tc.end) {
+			t.Errorf("Error in end of period %v\nExpected: %v\nReceived: %v", tc.period, tc.end, end)
+		}
+	}
+}
+
+func TestUnknownPeriod(t *testing.T) {
+	_, err := NewPeriodicLeaderBoard(nil, "kills", &PeriodicOptions{Period: "dayly"})
+	expectUnknownConfig(t, "periodic", "dayly", err)
+}
+
+func TestPeriodicLeaderboard(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+	defer func() {
+		now = time.Now
+	}()
+
+	ctx := context.Background()
+	clock := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	setClock(t, clock)
+
+	leaderboard, err := NewPeriodicLeaderBoard(redisClient, "kills", &PeriodicOptions{
+		Period:    PeriodDaily,
+		Retention: 48 * time.Hour,
+	})
+	if err != nil {
+		t.Fatal("failed to create periodic leaderboard", err.Error())
+	}
+	defer clean(t, ctx, leaderboard.At(clock))
+
+	addMember(t, ctx, leaderboard.Current(), "P1", 10)
+
+	ttl, err := redisClient.TTL(ctx, generateRankSetName("kills:2026-10-16")).Result()
+	if err != nil {
+		t.Fatal("failed to get ttl of board", err.Error())
+	}
+
+	// the board ends in 12 hours and is kept 48 hours more
+	if ttl != 60*time.Hour {
+		t.Errorf("Error in retention of periodic leaderboard\nExpected: %v\nReceived: %v", 60*time.Hour, ttl)
+	}
+
+	setClock(t, clock.Add(24*time.Hour))
+	defer clean(t, ctx, leaderboard.Current())
+
+	_, err = leaderboard.Current().GetRank(ctx, "P1")
+	if !errors.Is(err, ErrMemberNotFound) {
+		t.Errorf("Error in get rank of member in new period\nExpected: %v\nReceived: %v", ErrMemberNotFound, err)
+	}
+
+	addMember(t, ctx, leaderboard.Current(), "P2", 5)
+	getRank(t, ctx, leaderboard.At(clock), "P1", 1)
+	getRank(t, ctx, leaderboard.Current(), "P2", 1)
+}
@@ -0,0 +1,100 @@
+package goleaderboard
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// Score is the type of scores of members.
+// Scores are stored as float64 in Redis, so absolute value of integer scores must be less than 2^53 to be stored exactly.
+type Score interface {
+	~int | ~int64 | ~float64
+}
+
+// maxExactScore is the max absolute integer score which can be stored exactly in a float64,
+// results of sums over it are still greater than it after rounding.
+const maxExactScore = 1<<53 - 1
+
+// isFloatScore reports whether scores of type S can have a fraction.
+func isFloatScore[S Score]() bool {
+	half := 0.5
+	return S(half) != 0
+}
+
+func formatScore[S Score](score S) string {
+	if isFloatScore[S]() {
+		return strconv.FormatFloat(float64(score), 'g', -1, 64)
+	}
+
+	return strconv.FormatInt(int64(score), 10)
+}
+
+// encodeScore check a score can be stored without losing precision and convert it to float64.
+func (l *TypedRedisLeaderboard[ID, S]) encodeScore(score S) (float64, error) {
+	value := float64(score)
+	if math.IsNaN(value) {
+		return 0, ErrScoreOutOfRange
+	}
+
+	max := l.maxScore()
+	if isFloatScore[S]() {
+		// float scores are only limited by tie break, which stores the time in their fraction
+		if max > 0 && (value != math.Trunc(value) || math.Abs(value) > float64(max)) {
+			return 0, ErrScoreOutOfRange
+		}
+		return value, nil
+	}
+
+	if int64(score) > max || int64(score) < -max {
+		return 0, ErrScoreOutOfRange
+	}
+
+	return value, nil
+}
+
+// decodeScore convert a stored score to score of member.
+// It returns ErrScoreOutOfRange if the stored score is not a valid score of type S, for example it is not an integer.
+func (l *TypedRedisLeaderboard[ID, S]) decodeScore(stored float64) (S, error) {
+	if l.useTieBreak() {
+		stored = math.Floor(stored / tieBreakScale)
+	}
+
+	if !isFloatScore[S]() && (stored != math.Trunc(stored) || math.Abs(stored) > maxExactScore) {
+		return 0, ErrScoreOutOfRange
+	}
+
+	return S(stored), nil
+}
+
+// parseScore decode a stored score returned by scripts.
+func (l *TypedRedisLeaderboard[ID, S]) parseScore(val interface{}) (S, error) {
+	switch v := val.(type) {
+	case int64:
+		return l.decodeScore(float64(v))
+	case string:
+		stored, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return 0, fmt.Errorf("goleaderboard: invalid score %q: %w", v, err)
+		}
+		return l.decodeScore(stored)
+	}
+
+	return 0, fmt.Errorf("goleaderboard: invalid score %v", val)
+}
+
+// parseRank decode a rank returned by scripts.
+func parseRank(val interface{}) (int, error) {
+	switch v := val.(type) {
+	case int64:
+		return int(v), nil
+	case string:
+		rank, err := strconv.Atoi(v)
+		if err != nil {
+			return 0, fmt.Errorf("goleaderboard: invalid rank %q: %w", v, err)
+		}
+		return rank, nil
+	}
+
+	return 0, fmt.Errorf("goleaderboard: invalid rank %v", val)
+}
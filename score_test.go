@@ -0,0 +1,136 @@
+package goleaderboard
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-redis/redis/v8"
+)
+
+func TestFloatScore(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	testCases := []struct {
+		allowSameRank bool
+	}{
+		{
+			allowSameRank: false,
+		},
+		{
+			allowSameRank: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		ctx := context.Background()
+		leaderboard := NewTypedLeaderBoard[string, float64](redisClient, "test", StringCodec{}, &Options{AllowSameRank: tc.allowSameRank})
+		defer clean(t, ctx, leaderboard)
+
+		err := leaderboard.AddMembers(ctx, []TypedMember[string, float64]{
+			{ID: "P1", Score: 92.5},
+			{ID: "P2", Score: 87.25},
+			{ID: "P3", Score: 60},
+		})
+		if err != nil {
+			t.Error("failed to add members", err.Error())
+			return
+		}
+
+		member, err := leaderboard.IncrementScore(ctx, "P2", 5.75)
+		if err != nil {
+			t.Error("failed to increment score", err.Error())
+			return
+		}
+
+		if member.Score != 93 || member.Rank != 1 {
+			t.Errorf("Error in increment float score, same rank %v\nExpected: score 93 at rank #1\nReceived: %+v", tc.allowSameRank, member)
+		}
+
+		members, _, err := leaderboard.ListByScore(ctx, Inclusive(60.5), MaxScore, 0, 10, OrderDesc)
+		if err != nil {
+			t.Error("failed to list members by score", err.Error())
+			return
+		}
+
+		if len(members) != 2 || members[1].ID != "P1" || members[1].Score != 92.5 || members[1].Rank != 2 {
+			t.Errorf("Error in list member with float score, same rank %v\nExpected: P1 with score 92.5 at rank #2\nReceived: %+v", tc.allowSameRank, members)
+		}
+		clean(t, ctx, leaderboard)
+	}
+}
+
+func TestInt64Score(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	testCases := []struct {
+		allowSameRank bool
+	}{
+		{
+			allowSameRank: false,
+		},
+		{
+			allowSameRank: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		ctx := context.Background()
+		leaderboard := NewTypedLeaderBoard[string, int64](redisClient, "test", StringCodec{}, &Options{AllowSameRank: tc.allowSameRank})
+		defer clean(t, ctx, leaderboard)
+
+		err := leaderboard.AddMember(ctx, "PMax", maxExactScore)
+		if err != nil {
+			t.Error("failed to add member", err.Error())
+			return
+		}
+
+		member, err := leaderboard.GetMember(ctx, "PMax")
+		if err != nil {
+			t.Error("failed to get member", err.Error())
+			return
+		}
+
+		if member.Score != maxExactScore {
+			t.Errorf("Error in get member with int64 score, same rank %v\nExpected: %v\nReceived: %v", tc.allowSameRank, int64(maxExactScore), member.Score)
+		}
+
+		err = leaderboard.AddMember(ctx, "PHuge", maxExactScore+1)
+		if !errors.Is(err, ErrScoreOutOfRange) {
+			t.Errorf("Error in add member with huge score, same rank %v\nExpected: %v\nReceived: %v", tc.allowSameRank, ErrScoreOutOfRange, err)
+		}
+
+		_, err = leaderboard.IncrementScore(ctx, "PMax", 1)
+		if !errors.Is(err, ErrScoreOutOfRange) {
+			t.Errorf("Error in increment score over max, same rank %v\nExpected: %v\nReceived: %v", tc.allowSameRank, ErrScoreOutOfRange, err)
+		}
+		clean(t, ctx, leaderboard)
+	}
+}
+
+func TestDecodeScoreError(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	ctx := context.Background()
+	leaderboard := NewLeaderBoard(redisClient, "test", nil)
+	defer clean(t, ctx, leaderboard)
+
+	// a fraction can not be decoded to an int score
+	err := redisClient.ZAdd(ctx, generateRankSetName("test"), &redis.Z{Member: "P1", Score: 1.5}).Err()
+	if err != nil {
+		t.Fatal("failed to add member", err.Error())
+	}
+
+	_, err = leaderboard.GetMember(ctx, "P1")
+	if !errors.Is(err, ErrScoreOutOfRange) {
+		t.Errorf("Error in get member with invalid score\nExpected: %v\nReceived: %v", ErrScoreOutOfRange, err)
+	}
+
+	_, _, err = leaderboard.List(ctx, 0, 10, OrderDesc)
+	if !errors.Is(err, ErrScoreOutOfRange) {
+		t.Errorf("Error in list member with invalid score\nExpected: %v\nReceived: %v", ErrScoreOutOfRange, err)
+	}
+}
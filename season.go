@@ -0,0 +1,273 @@
+package goleaderboard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// ErrReadOnly is returned when an archived season is written.
+var ErrReadOnly = errors.New("goleaderboard: leaderboard is read only")
+
+// ErrSeasonArchived is returned when a season is ended with the name of a season which is already archived.
+var ErrSeasonArchived = errors.New("goleaderboard: season is already archived")
+
+// SeasonOptions contains all configs for season leaderboard
+type SeasonOptions struct {
+	// Retention is how long an archived season is kept after it ends, 0 means archives never expire.
+	Retention time.Duration
+	// Options is configs of the live board and of archived seasons.
+	Options Options
+}
+
+// TypedSeasonLeaderboard defines a leaderboard stored in Redis which is played in seasons.
+// Members are added to the live board, when a season ends the live board is archived by its season name
+// and a new season starts from an empty board, or from a fraction of scores of the ended season.
+type TypedSeasonLeaderboard[ID comparable, S Score] struct {
+	redisClient     redis.UniversalClient
+	name            string
+	codec           IDCodec[ID]
+	endSeasonScript *redis.Script
+	opts            *SeasonOptions
+}
+
+// SeasonLeaderboard defines a season leaderboard with member ids of any type.
+type SeasonLeaderboard = TypedSeasonLeaderboard[interface{}, int]
+
+// NewSeasonLeaderBoard create a new season leaderboard stored in Redis with specific name and configs.
+// The live board has the name of leaderboard, so an existing leaderboard can be played in seasons.
+func NewSeasonLeaderBoard(redisClient redis.UniversalClient, name string, opts *SeasonOptions) (*SeasonLeaderboard, error) {
+	return NewTypedSeasonLeaderBoard[interface{}, int](redisClient, name, AnyCodec{}, opts)
+}
+
+// NewTypedSeasonLeaderBoard create a new season leaderboard stored in Redis whose member ids have type ID
+// and are encoded by codec, scores have type S. It returns an error if a config of Options has an unknown value.
+func NewTypedSeasonLeaderBoard[ID comparable, S Score](redisClient redis.UniversalClient, name string, codec IDCodec[ID], opts *SeasonOptions) (*TypedSeasonLeaderboard[ID, S], error) {
+	if opts == nil {
+		opts = &SeasonOptions{}
+	}
+
+	if err := opts.Options.validate(); err != nil {
+		return nil, err
+	}
+
+	return &TypedSeasonLeaderboard[ID, S]{
+		redisClient:     redisClient,
+		name:            name,
+		codec:           codec,
+		endSeasonScript: redis.NewScript(initEndSeasonScript()),
+		opts:            opts,
+	}, nil
+}
+
+func (s *TypedSeasonLeaderboard[ID, S]) live() *TypedRedisLeaderboard[ID, S] {
+	opts := s.opts.Options
+	return newTypedRedisLeaderBoard[ID, S](s.redisClient, s.name, s.codec, &opts)
+}
+
+// archiveName get name of board of an archived season, for example "kills:season:2026-S1".
+// With Options.ClusterKeys it has the hash tag of the live board, so they are in the same slot of Redis Cluster.
+func (s *TypedSeasonLeaderboard[ID, S]) archiveName(season string) string {
+	return fmt.Sprintf("%s:season:%s", s.opts.Options.keyName(s.name), season)
+}
+
+// seasonSet get key of the set of archived seasons, they are scored by time they were archived.
+func (s *TypedSeasonLeaderboard[ID, S]) seasonSet() string {
+	return generateSeasonSetName(s.opts.Options.keyName(s.name))
+}
+
+// Current get the live board of the current season, members should be added to it.
+func (s *TypedSeasonLeaderboard[ID, S]) Current() TypedLeaderboard[ID, S] {
+	return s.live()
+}
+
+// Archive get board of an archived season, it can be read like the live board but writes return ErrReadOnly.
+// Board of a season which is not archived is empty.
+func (s *TypedSeasonLeaderboard[ID, S]) Archive(season string) TypedLeaderboard[ID, S] {
+	opts := s.opts.Options
+	opts.LifeTime = 0
+
+	return &readOnlyLeaderboard[ID, S]{
+		TypedLeaderboard: NewTypedLeaderBoard[ID, S](s.redisClient, s.archiveName(season), s.codec, &opts),
+	}
+}
+
+// EndSeason archive the live board by season name and start a new season atomically.
+// The new season starts with carry times the score of every member of the ended season, carry is in range [0, 1]
+// and 0 starts from an empty board. Carried integer scores are truncated toward zero.
+// It returns ErrSeasonArchived if season is already archived.
+func (s *TypedSeasonLeaderboard[ID, S]) EndSeason(ctx context.Context, season string, carry float64) error {
+	if carry < 0 || carry > 1 {
+		return fmt.Errorf("goleaderboard: carry must be in range [0, 1], got %v", carry)
+	}
+
+	live := s.live()
+	archive := newTypedRedisLeaderBoard[ID, S](s.redisClient, s.archiveName(season), s.codec, live.opts)
+	keys := append(live.keys(), archive.keys()...)
+	keys = append(keys, s.seasonSet())
+
+	scale, time := live.tieBreakArgs()
+	err := s.endSeasonScript.Run(
+		ctx,
+		s.redisClient,
+		keys,
+		season,
+		strconv.FormatFloat(carry, 'g', -1, 64),
+		scale,
+		time,
+		!isFloatScore[S](),
+		live.allowSameRank(),
+		s.opts.Retention.Milliseconds(),
+		now().UnixMilli(),
+	).Err()
+	if err != nil {
+		return parseScriptError(err)
+	}
+
+	live.setTTL(ctx)
+	return nil
+}
+
+// Seasons get names of archived seasons which are not expired, from the oldest one to the latest one.
+func (s *TypedSeasonLeaderboard[ID, S]) Seasons(ctx context.Context) ([]string, error) {
+	min := "-inf"
+	if s.opts.Retention > 0 {
+		min = "(" + strconv.FormatInt(now().Add(-s.opts.Retention).UnixMilli(), 10)
+	}
+
+	return s.redisClient.ZRangeByScore(ctx, s.seasonSet(), &redis.ZRangeBy{
+		Min: min,
+		Max: "+inf",
+	}).Result()
+}
+
+// DeleteArchive clear all data of an archived season in redis
+func (s *TypedSeasonLeaderboard[ID, S]) DeleteArchive(ctx context.Context, season string) error {
+	opts := s.opts.Options
+	archive := newTypedRedisLeaderBoard[ID, S](s.redisClient, s.archiveName(season), s.codec, &opts)
+	pipeline := s.redisClient.TxPipeline()
+	pipeline.Del(ctx, archive.keys()...)
+	pipeline.ZRem(ctx, s.seasonSet(), season)
+
+	_, err := pipeline.Exec(ctx)
+	return err
+}
+
+// readOnlyLeaderboard reads a leaderboard and rejects writes with ErrReadOnly.
+type readOnlyLeaderboard[ID comparable, S Score] struct {
+	TypedLeaderboard[ID, S]
+}
+
+func (l *readOnlyLeaderboard[ID, S]) AddMember(ctx context.Context, id ID, score S) error {
+	return ErrReadOnly
+}
+
+func (l *readOnlyLeaderboard[ID, S]) AddMemberWithMetadata(ctx context.Context, id ID, score S, metadata map[string]string) error {
+	return ErrReadOnly
+}
+
+func (l *readOnlyLeaderboard[ID, S]) AddMembers(ctx context.Context, members []TypedMember[ID, S]) error {
+	return ErrReadOnly
+}
+
+func (l *readOnlyLeaderboard[ID, S]) UpdateMember(ctx context.Context, id ID, score S, policy UpdatePolicy) (bool, error) {
+	return false, ErrReadOnly
+}
+
+func (l *readOnlyLeaderboard[ID, S]) IncrementScore(ctx context.Context, id ID, delta S) (*TypedMember[ID, S], error) {
+	return nil, ErrReadOnly
+}
+
+func (l *readOnlyLeaderboard[ID, S]) RemoveMember(ctx context.Context, ids ...ID) error {
+	return ErrReadOnly
+}
+
+func (l *readOnlyLeaderboard[ID, S]) Clean(ctx context.Context) error {
+	return ErrReadOnly
+}
+
+func initEndSeasonScript() string {
+	return `
+local season = ARGV[1]
+local carry = tonumber(ARGV[2])
+local scale = tonumber(ARGV[3])
+local time = tonumber(ARGV[4])
+local truncate = ARGV[5] == "1"
+local same_rank = ARGV[6] == "1"
+local retention = tonumber(ARGV[7])
+local now = tonumber(ARGV[8])
+
+-- keys are rank set, member score set and metadata of the live board then of the archive, then the season set
+local live_keys = {KEYS[1], KEYS[2], KEYS[3]}
+local archive_keys = {KEYS[4], KEYS[5], KEYS[6]}
+local season_set = KEYS[7]
+
+-- archived seasons are listed by the time they end, expired ones are forgotten
+if retention > 0 then
+	redis.call("ZREMRANGEBYSCORE", season_set, "-inf", now - retention)
+end
+
+if redis.call("ZSCORE", season_set, season) then
+	return redis.error_reply("goleaderboard: season is already archived")
+end
+
+for idx, live_key in ipairs(live_keys) do
+	if redis.call("EXISTS", live_key) == 1 then
+		redis.call("RENAME", live_key, archive_keys[idx])
+		if retention > 0 then
+			redis.call("PEXPIRE", archive_keys[idx], retention)
+		else
+			redis.call("PERSIST", archive_keys[idx])
+		end
+	end
+end
+
+redis.call("ZADD", season_set, now, season)
+
+if carry == 0 then
+	return 0
+end
+
+local member_set_idx = 1
+if same_rank then
+	member_set_idx = 2
+end
+
+local member_set = live_keys[member_set_idx]
+local rank_set = live_keys[1]
+
+-- score of member is stored as score * scale + time, time is 0 and scale is 1 without tie break
+local scores = redis.call("ZRANGE", archive_keys[member_set_idx], 0, -1, "WITHSCORES")
+for idx = 1, #scores, 2 do
+	local score = tonumber(scores[idx + 1])
+	if scale ~= 1 then
+		score = math.floor(score / scale)
+	end
+
+	score = score * carry
+	if truncate and score >= 0 then
+		score = math.floor(score)
+	elseif truncate then
+		score = math.ceil(score)
+	end
+
+	redis.call("ZADD", member_set, score * scale + time, scores[idx])
+	if same_rank then
+		local new_score = redis.call("ZSCORE", member_set, scores[idx])
+		redis.call("ZADD", rank_set, new_score, new_score)
+	end
+end
+
+-- metadata describes members, so it is carried with them
+local metadata = redis.call("HGETALL", archive_keys[3])
+for idx = 1, #metadata, 2 do
+	redis.call("HSET", live_keys[3], metadata[idx], metadata[idx + 1])
+end
+
+return #scores / 2
+`
+}
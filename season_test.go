@@ -0,0 +1,104 @@
+package goleaderboard
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSeasonLeaderboard(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+	defer func() {
+		now = time.Now
+	}()
+
+	testCases := []struct {
+		allowSameRank bool
+	}{
+		{
+			allowSameRank: false,
+		},
+		{
+			allowSameRank: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		ctx := context.Background()
+		setClock(t, time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC))
+
+		leaderboard, err := NewSeasonLeaderBoard(redisClient, "test", &SeasonOptions{
+			Options: Options{AllowSameRank: tc.allowSameRank, IncludeMetadata: true},
+		})
+		if err != nil {
+			t.Fatal("failed to create season leaderboard", err.Error())
+		}
+		defer clean(t, ctx, leaderboard.Current())
+
+		err = leaderboard.Current().AddMemberWithMetadata(ctx, "P1", 15, map[string]string{"name": "Alice"})
+		if err != nil {
+			t.Error("failed to add member with metadata", err.Error())
+			return
+		}
+		addMember(t, ctx, leaderboard.Current(), "P2", 20)
+		addMember(t, ctx, leaderboard.Current(), "P3", -5)
+
+		if err := leaderboard.EndSeason(ctx, "S1", 0.5); err != nil {
+			t.Error("failed to end season", err.Error())
+			return
+		}
+
+		archive := leaderboard.Archive("S1")
+		getRank(t, ctx, archive, "P2", 1)
+		getRank(t, ctx, archive, "P1", 2)
+
+		if err := archive.AddMember(ctx, "P1", 100); !errors.Is(err, ErrReadOnly) {
+			t.Errorf("Error in add member to archive, same rank %v\nExpected: %v\nReceived: %v", tc.allowSameRank, ErrReadOnly, err)
+		}
+
+		members, cursor, err := leaderboard.Current().List(ctx, 0, 10, OrderDesc)
+		if err != nil {
+			t.Error("failed to list members", err.Error())
+			return
+		}
+
+		// scores are halved and truncated toward zero
+		if cursor.Total != 3 || members[0].Score != 10 || members[1].Score != 7 || members[2].Score != -2 {
+			t.Errorf("Error in carry scores to new season, same rank %v\nExpected: 10, 7, -2\nReceived: %+v", tc.allowSameRank, members)
+		}
+
+		if members[1].Metadata["name"] != "Alice" {
+			t.Errorf("Error in carry metadata to new season, same rank %v\nExpected: Alice\nReceived: %v", tc.allowSameRank, members[1].Metadata)
+		}
+
+		addMember(t, ctx, leaderboard.Current(), "P4", 30)
+		if err := leaderboard.EndSeason(ctx, "S2", 0); err != nil {
+			t.Error("failed to end season", err.Error())
+			return
+		}
+
+		if err := leaderboard.EndSeason(ctx, "S2", 0); !errors.Is(err, ErrSeasonArchived) {
+			t.Errorf("Error in end archived season, same rank %v\nExpected: %v\nReceived: %v", tc.allowSameRank, ErrSeasonArchived, err)
+		}
+
+		total, err := leaderboard.Current().Count(ctx)
+		if err != nil || total != 0 {
+			t.Errorf("Error in start new season, same rank %v\nExpected: empty board\nReceived: %v, %v", tc.allowSameRank, total, err)
+		}
+
+		getRank(t, ctx, leaderboard.Archive("S2"), "P4", 1)
+
+		seasons, err := leaderboard.Seasons(ctx)
+		if err != nil || len(seasons) != 2 || seasons[0] != "S1" || seasons[1] != "S2" {
+			t.Errorf("Error in list seasons, same rank %v\nExpected: [S1 S2]\nReceived: %v, %v", tc.allowSameRank, seasons, err)
+		}
+
+		for _, season := range seasons {
+			if err := leaderboard.DeleteArchive(ctx, season); err != nil {
+				t.Fatal("failed to delete archive", err.Error())
+			}
+		}
+	}
+}
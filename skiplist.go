@@ -0,0 +1,171 @@
+package goleaderboard
+
+import (
+	"math/rand"
+)
+
+// skiplistMaxLevel is enough for 4^32 keys.
+const skiplistMaxLevel = 32
+
+type skiplistNode[K any] struct {
+	key  K
+	next []*skiplistNode[K]
+	// span is the number of keys which are skipped to reach next node at every level
+	span []int
+}
+
+// skiplist is an order-statistic skiplist like the one of sorted sets in Redis,
+// it finds keys and their index in O(log n). Keys are unique and sorted by less.
+type skiplist[K any] struct {
+	less   func(a, b K) bool
+	head   *skiplistNode[K]
+	level  int
+	length int
+}
+
+func newSkiplist[K any](less func(a, b K) bool) *skiplist[K] {
+	return &skiplist[K]{
+		less: less,
+		head: &skiplistNode[K]{
+			next: make([]*skiplistNode[K], skiplistMaxLevel),
+			span: make([]int, skiplistMaxLevel),
+		},
+		level: 1,
+	}
+}
+
+func (s *skiplist[K]) randomLevel() int {
+	level := 1
+	for level < skiplistMaxLevel && rand.Intn(4) == 0 {
+		level++
+	}
+
+	return level
+}
+
+// Len get number of keys.
+func (s *skiplist[K]) Len() int {
+	return s.length
+}
+
+// Insert add key, it must not be in skiplist.
+func (s *skiplist[K]) Insert(key K) {
+	update := make([]*skiplistNode[K], skiplistMaxLevel)
+	rank := make([]int, skiplistMaxLevel)
+	node := s.head
+	for lvl := s.level - 1; lvl >= 0; lvl-- {
+		if lvl < s.level-1 {
+			rank[lvl] = rank[lvl+1]
+		}
+		for node.next[lvl] != nil && s.less(node.next[lvl].key, key) {
+			rank[lvl] += node.span[lvl]
+			node = node.next[lvl]
+		}
+		update[lvl] = node
+	}
+
+	level := s.randomLevel()
+	if level > s.level {
+		for lvl := s.level; lvl < level; lvl++ {
+			rank[lvl] = 0
+			update[lvl] = s.head
+			update[lvl].span[lvl] = s.length
+		}
+		s.level = level
+	}
+
+	node = &skiplistNode[K]{
+		key:  key,
+		next: make([]*skiplistNode[K], level),
+		span: make([]int, level),
+	}
+	for lvl := 0; lvl < level; lvl++ {
+		node.next[lvl] = update[lvl].next[lvl]
+		update[lvl].next[lvl] = node
+
+		node.span[lvl] = update[lvl].span[lvl] - (rank[0] - rank[lvl])
+		update[lvl].span[lvl] = rank[0] - rank[lvl] + 1
+	}
+
+	for lvl := level; lvl < s.level; lvl++ {
+		update[lvl].span[lvl]++
+	}
+
+	s.length++
+}
+
+// Delete remove key, it reports whether key was in skiplist.
+func (s *skiplist[K]) Delete(key K) bool {
+	update := make([]*skiplistNode[K], skiplistMaxLevel)
+	node := s.head
+	for lvl := s.level - 1; lvl >= 0; lvl-- {
+		for node.next[lvl] != nil && s.less(node.next[lvl].key, key) {
+			node = node.next[lvl]
+		}
+		update[lvl] = node
+	}
+
+	node = node.next[0]
+	if node == nil || s.less(key, node.key) {
+		return false
+	}
+
+	for lvl := 0; lvl < s.level; lvl++ {
+		if update[lvl].next[lvl] == node {
+			update[lvl].span[lvl] += node.span[lvl] - 1
+			update[lvl].next[lvl] = node.next[lvl]
+		} else {
+			update[lvl].span[lvl]--
+		}
+	}
+
+	for s.level > 1 && s.head.next[s.level-1] == nil {
+		s.level--
+	}
+
+	s.length--
+	return true
+}
+
+// CountWhile get number of keys from the first one for which pred is true, pred must be true for all keys before them.
+func (s *skiplist[K]) CountWhile(pred func(key K) bool) int {
+	count := 0
+	node := s.head
+	for lvl := s.level - 1; lvl >= 0; lvl-- {
+		for node.next[lvl] != nil && pred(node.next[lvl].key) {
+			count += node.span[lvl]
+			node = node.next[lvl]
+		}
+	}
+
+	return count
+}
+
+// Index get index of key from 0, key must be in skiplist.
+func (s *skiplist[K]) Index(key K) int {
+	return s.CountWhile(func(k K) bool {
+		return s.less(k, key)
+	})
+}
+
+// At get node of key at index from 0, it is nil if index is out of range.
+// Next keys are found by next[0] of node.
+func (s *skiplist[K]) At(index int) *skiplistNode[K] {
+	if index < 0 || index >= s.length {
+		return nil
+	}
+
+	traversed := -1
+	node := s.head
+	for lvl := s.level - 1; lvl >= 0; lvl-- {
+		for node.next[lvl] != nil && traversed+node.span[lvl] <= index {
+			traversed += node.span[lvl]
+			node = node.next[lvl]
+		}
+		if traversed == index {
+			return node
+		}
+	}
+
+	return nil
+}
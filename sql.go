@@ -0,0 +1,794 @@
+package goleaderboard
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SQLDialect is the way a database writes queries, SQLiteDialect is the reference one.
+// Tables are created with standard types, so they work in databases which support window functions
+// and ON CONFLICT, for example SQLite 3.25+ and PostgreSQL.
+type SQLDialect struct {
+	// Placeholder get the placeholder of the argument n of a query, n is counted from 1.
+	Placeholder func(n int) string
+	// IDCollation is the collation which orders ids by their bytes like Redis, for example "C" in PostgreSQL,
+	// empty uses the collation of the column, which is already binary in SQLite.
+	IDCollation string
+}
+
+var (
+	// SQLiteDialect writes placeholders like "?".
+	SQLiteDialect = SQLDialect{
+		Placeholder: func(n int) string {
+			return "?"
+		},
+	}
+	// PostgresDialect writes placeholders like "$1".
+	// Ids are ordered by collation "C", because the default one depends on locale of database.
+	PostgresDialect = SQLDialect{
+		Placeholder: func(n int) string {
+			return "$" + strconv.Itoa(n)
+		},
+		IDCollation: "C",
+	}
+)
+
+// orderID get the expression which orders members with the same score and time by id like Redis.
+func (d SQLDialect) orderID() string {
+	if d.IDCollation == "" {
+		return "id"
+	}
+
+	return fmt.Sprintf(`id COLLATE "%s"`, d.IDCollation)
+}
+
+// rebind replace placeholders "?" of a query by placeholders of dialect.
+func (d SQLDialect) rebind(query string) string {
+	if d.Placeholder == nil {
+		return query
+	}
+
+	var builder strings.Builder
+	n := 0
+	for _, char := range query {
+		if char != '?' {
+			builder.WriteRune(char)
+			continue
+		}
+
+		n++
+		builder.WriteString(d.Placeholder(n))
+	}
+
+	return builder.String()
+}
+
+// sqlMigrations are statements which create and upgrade tables of SQL leaderboards, a version is the index of a
+// statement from 1. New statements are only appended, so databases are upgraded from the version they have.
+var sqlMigrations = []string{
+	`CREATE TABLE goleaderboard_members (
+	board TEXT NOT NULL,
+	id TEXT NOT NULL,
+	score DOUBLE PRECISION NOT NULL,
+	tie_break BIGINT NOT NULL DEFAULT 0,
+	metadata TEXT,
+	PRIMARY KEY (board, id)
+)`,
+	`CREATE INDEX goleaderboard_members_score ON goleaderboard_members (board, score, tie_break, id)`,
+	`CREATE TABLE goleaderboard_boards (
+	board TEXT PRIMARY KEY,
+	expire_at BIGINT
+)`,
+}
+
+// SQLMigrations get statements which create tables of SQL leaderboards, in order.
+// They can be run by another migration tool instead of MigrateSQL.
+func SQLMigrations() []string {
+	return append([]string{}, sqlMigrations...)
+}
+
+// MigrateSQL create or upgrade tables of SQL leaderboards to the latest version in one transaction.
+// Applied versions are kept in table goleaderboard_schema_migrations, so it can be run at every start.
+func MigrateSQL(ctx context.Context, db *sql.DB, dialect SQLDialect) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS goleaderboard_schema_migrations (version INTEGER PRIMARY KEY)`)
+	if err != nil {
+		return err
+	}
+
+	version := 0
+	err = tx.QueryRowContext(ctx, `SELECT COALESCE(MAX(version), 0) FROM goleaderboard_schema_migrations`).Scan(&version)
+	if err != nil {
+		return err
+	}
+
+	for ; version < len(sqlMigrations); version++ {
+		if _, err := tx.ExecContext(ctx, sqlMigrations[version]); err != nil {
+			return fmt.Errorf("goleaderboard: failed to migrate to version %v: %w", version+1, err)
+		}
+
+		_, err = tx.ExecContext(ctx, dialect.rebind(`INSERT INTO goleaderboard_schema_migrations (version) VALUES (?)`), version+1)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// sqlQuerier is a database or a transaction.
+type sqlQuerier interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// TypedSQLLeaderboard defines a leaderboard stored in a SQL database whose member ids have type ID and scores have type S,
+// follows TypedLeaderboard interface. Members of all leaderboards are rows of table goleaderboard_members, so they can be
+// joined with other tables. Ranks are computed by window functions, tables are created by MigrateSQL.
+type TypedSQLLeaderboard[ID comparable, S Score] struct {
+	db      *sql.DB
+	dialect SQLDialect
+	name    string
+	codec   IDCodec[ID]
+	opts    *Options
+}
+
+// SQLLeaderboard defines a leaderboard stored in a SQL database with member ids of any type, follows Leaderboard interface
+type SQLLeaderboard = TypedSQLLeaderboard[interface{}, int]
+
+// NewSQLLeaderBoard create a new leaderboard stored in a SQL database with specific name and configs.
+// You can see all supported config in type `Options`, if a config has an unknown value every operation returns an error.
+func NewSQLLeaderBoard(db *sql.DB, dialect SQLDialect, name string, opts *Options) Leaderboard {
+	return NewTypedSQLLeaderBoard[interface{}, int](db, dialect, name, AnyCodec{}, opts)
+}
+
+// NewTypedSQLLeaderBoard create a new leaderboard stored in a SQL database whose member ids have type ID and are encoded
+// by codec, scores have type S.
+// You can see all supported config in type `Options`, if a config has an unknown value every operation returns an error.
+func NewTypedSQLLeaderBoard[ID comparable, S Score](db *sql.DB, dialect SQLDialect, name string, codec IDCodec[ID], opts *Options) TypedLeaderboard[ID, S] {
+	if opts == nil {
+		opts = &Options{
+			RankingScheme: RankOrdinal,
+			LifeTime:      1 * time.Hour,
+		}
+	}
+	if err := opts.validate(); err != nil {
+		return &invalidLeaderboard[ID, S]{err: err}
+	}
+
+	return &TypedSQLLeaderboard[ID, S]{
+		db:      db,
+		dialect: dialect,
+		name:    name,
+		codec:   codec,
+		opts:    opts,
+	}
+}
+
+// options get configs of leaderboard, so leaderboards built on it like CompositeLeaderboard can check them.
+func (l *TypedSQLLeaderboard[ID, S]) options() *Options {
+	return l.opts
+}
+
+// rankFunction get the window function which ranks members by Options.RankingScheme.
+func (l *TypedSQLLeaderboard[ID, S]) rankFunction() string {
+	switch l.opts.rankingScheme() {
+	case RankDense:
+		return "DENSE_RANK() OVER (ORDER BY score DESC)"
+	case RankStandardCompetition:
+		return "RANK() OVER (ORDER BY score DESC)"
+	case RankModifiedCompetition:
+		// the default frame ends at the last member with the same score
+		return "COUNT(*) OVER (ORDER BY score DESC)"
+	default:
+		return "ROW_NUMBER() OVER (ORDER BY score DESC, tie_break DESC, " + l.dialect.orderID() + " DESC)"
+	}
+}
+
+// members get the clause which selects members of leaderboard with its arguments, it selects no members if
+// leaderboard expired.
+func (l *TypedSQLLeaderboard[ID, S]) members() (string, []interface{}) {
+	return `FROM goleaderboard_members AS m
+WHERE m.board = ? AND NOT EXISTS (
+	SELECT 1 FROM goleaderboard_boards AS b WHERE b.board = m.board AND b.expire_at <= ?
+)`, []interface{}{l.name, now().UnixNano()}
+}
+
+// ranked get the query of members of leaderboard with their rank and position from 1 in descending order,
+// with its arguments.
+func (l *TypedSQLLeaderboard[ID, S]) ranked() (string, []interface{}) {
+	members, args := l.members()
+	query := fmt.Sprintf(`SELECT id, score, metadata, %s AS member_rank,
+	ROW_NUMBER() OVER (ORDER BY score DESC, tie_break DESC, %s DESC) AS member_position
+%s`, l.rankFunction(), l.dialect.orderID(), members)
+
+	return query, args
+}
+
+// scoreCondition get the condition of score range [min, max] with its arguments.
+func scoreCondition(min, max ScoreBound) (string, []interface{}, error) {
+	minScore, minExclusive, err := parseScoreBound(min)
+	if err != nil {
+		return "", nil, err
+	}
+
+	maxScore, maxExclusive, err := parseScoreBound(max)
+	if err != nil {
+		return "", nil, err
+	}
+
+	conditions := []string{"1 = 1"}
+	args := []interface{}{}
+	for _, bound := range []struct {
+		score     float64
+		exclusive bool
+		operator  string
+	}{
+		{minScore, minExclusive, ">"},
+		{maxScore, maxExclusive, "<"},
+	} {
+		// infinite scores are not sent to database, they exclude all scores if they are on the wrong side of range
+		if math.IsInf(bound.score, 0) {
+			if (bound.operator == ">") == (bound.score > 0) {
+				conditions = append(conditions, "1 = 0")
+			}
+			continue
+		}
+
+		operator := bound.operator
+		if !bound.exclusive {
+			operator += "="
+		}
+		conditions = append(conditions, "score "+operator+" ?")
+		args = append(args, bound.score)
+	}
+
+	return strings.Join(conditions, " AND "), args, nil
+}
+
+// queryMembers get members with their rank from rows of id, score, metadata and rank.
+func (l *TypedSQLLeaderboard[ID, S]) queryMembers(ctx context.Context, querier sqlQuerier, query string, args ...interface{}) ([]*TypedMember[ID, S], error) {
+	rows, err := querier.QueryContext(ctx, l.dialect.rebind(query), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	listMember := []*TypedMember[ID, S]{}
+	for rows.Next() {
+		var memberID string
+		var score float64
+		var metadata sql.NullString
+		var rank int
+		if err := rows.Scan(&memberID, &score, &metadata, &rank); err != nil {
+			return nil, err
+		}
+
+		id, err := l.codec.DecodeID(memberID)
+		if err != nil {
+			return nil, err
+		}
+
+		member := &TypedMember[ID, S]{
+			ID:    id,
+			Score: S(score),
+			Rank:  rank,
+		}
+
+		if l.opts.IncludeMetadata && metadata.Valid {
+			if err := json.Unmarshal([]byte(metadata.String), &member.Metadata); err != nil {
+				return nil, err
+			}
+		}
+		listMember = append(listMember, member)
+	}
+
+	return listMember, rows.Err()
+}
+
+// queryInt get an integer selected by a query.
+func (l *TypedSQLLeaderboard[ID, S]) queryInt(ctx context.Context, query string, args ...interface{}) (int, error) {
+	value := 0
+	err := l.db.QueryRowContext(ctx, l.dialect.rebind(query), args...).Scan(&value)
+	return value, err
+}
+
+// inClause get placeholders of a list of values for operator IN.
+func inClause(size int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", size), ", ")
+}
+
+// transaction run fn in a transaction which is committed if fn returns no error.
+// Leaderboard is cleared if it expired, and its expiry is extended by Options.LifeTime.
+func (l *TypedSQLLeaderboard[ID, S]) transaction(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := l.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, l.dialect.rebind(`DELETE FROM goleaderboard_members
+WHERE board = ? AND EXISTS (
+	SELECT 1 FROM goleaderboard_boards AS b WHERE b.board = goleaderboard_members.board AND b.expire_at <= ?
+)`), l.name, now().UnixNano())
+	if err != nil {
+		return err
+	}
+
+	// a leaderboard without expiry has a NULL expire_at
+	var expireAt interface{}
+	if l.opts.LifeTime > 0 {
+		expireAt = now().Add(l.opts.lifeTime()).UnixNano()
+	}
+
+	_, err = tx.ExecContext(ctx, l.dialect.rebind(`INSERT INTO goleaderboard_boards (board, expire_at) VALUES (?, ?)
+ON CONFLICT (board) DO UPDATE SET expire_at = excluded.expire_at`), l.name, expireAt)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// update apply policy to score of a member in a transaction, it reports whether the score was changed.
+func (l *TypedSQLLeaderboard[ID, S]) update(ctx context.Context, tx *sql.Tx, memberID string, score S, policy UpdatePolicy) (bool, error) {
+	var stored float64
+	err := tx.QueryRowContext(ctx, l.dialect.rebind(`SELECT score FROM goleaderboard_members WHERE board = ? AND id = ?`), l.name, memberID).Scan(&stored)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return false, err
+	}
+
+	if err == nil {
+		oldScore := S(stored)
+		switch policy {
+		case UpdateSum:
+			score = oldScore + score
+		case UpdateKeepHighest:
+			if score < oldScore {
+				score = oldScore
+			}
+		case UpdateKeepLowest:
+			if score > oldScore {
+				score = oldScore
+			}
+		}
+
+		if score == oldScore {
+			return false, nil
+		}
+	}
+
+	if err := checkExactScore(score); err != nil {
+		return false, err
+	}
+
+	_, err = tx.ExecContext(ctx, l.dialect.rebind(`INSERT INTO goleaderboard_members (board, id, score, tie_break) VALUES (?, ?, ?, ?)
+ON CONFLICT (board, id) DO UPDATE SET score = excluded.score, tie_break = excluded.tie_break`), l.name, memberID, float64(score), keyTieBreakTime(l.opts))
+	return err == nil, err
+}
+
+// AddMember add a member with score to leaderboard.
+// Score of a member which was already in leaderboard is updated by Options.UpdatePolicy.
+func (l *TypedSQLLeaderboard[ID, S]) AddMember(ctx context.Context, id ID, score S) error {
+	_, err := l.UpdateMember(ctx, id, score, l.opts.updatePolicy())
+	return err
+}
+
+// AddMemberWithMetadata add a member with score to leaderboard like AddMember, and store metadata of member.
+// Metadata replaces the one stored before, nil metadata keeps it.
+func (l *TypedSQLLeaderboard[ID, S]) AddMemberWithMetadata(ctx context.Context, id ID, score S, metadata map[string]string) error {
+	return l.AddMembers(ctx, []TypedMember[ID, S]{{ID: id, Score: score, Metadata: metadata}})
+}
+
+// AddMembers add a list of members with their score to leaderboard in one transaction.
+// It works the same as AddMember for every member, field Rank of members is ignored.
+// Metadata of members is stored like AddMemberWithMetadata if it is not nil.
+func (l *TypedSQLLeaderboard[ID, S]) AddMembers(ctx context.Context, members []TypedMember[ID, S]) error {
+	if len(members) == 0 {
+		return nil
+	}
+
+	memberIDs := make([]string, 0, len(members))
+	for _, member := range members {
+		memberID, err := l.codec.EncodeID(member.ID)
+		if err != nil {
+			return err
+		}
+
+		if err := checkExactScore(member.Score); err != nil {
+			return err
+		}
+		memberIDs = append(memberIDs, memberID)
+	}
+
+	return l.transaction(ctx, func(tx *sql.Tx) error {
+		for idx, member := range members {
+			if _, err := l.update(ctx, tx, memberIDs[idx], member.Score, l.opts.updatePolicy()); err != nil {
+				return err
+			}
+
+			if member.Metadata == nil {
+				continue
+			}
+
+			value, err := json.Marshal(member.Metadata)
+			if err != nil {
+				return err
+			}
+
+			_, err = tx.ExecContext(ctx, l.dialect.rebind(`UPDATE goleaderboard_members SET metadata = ? WHERE board = ? AND id = ?`), string(value), l.name, memberIDs[idx])
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// UpdateMember add a member with score to leaderboard like AddMember, but update its score by policy instead of
+// Options.UpdatePolicy. It reports whether the stored score was changed, or returns an error if policy is unknown.
+func (l *TypedSQLLeaderboard[ID, S]) UpdateMember(ctx context.Context, id ID, score S, policy UpdatePolicy) (bool, error) {
+	if err := checkUpdatePolicy(policy); err != nil {
+		return false, err
+	}
+
+	memberID, err := l.codec.EncodeID(id)
+	if err != nil {
+		return false, err
+	}
+
+	if err := checkExactScore(score); err != nil {
+		return false, err
+	}
+
+	updated := false
+	err = l.transaction(ctx, func(tx *sql.Tx) error {
+		updated, err = l.update(ctx, tx, memberID, score, policy)
+		return err
+	})
+
+	return updated, err
+}
+
+// IncrementScore increase score of a member by delta atomically, a negative delta will decrease it.
+// If member was not in leaderboard, it will be added with score is delta.
+// It returns the member with new score and rank.
+func (l *TypedSQLLeaderboard[ID, S]) IncrementScore(ctx context.Context, id ID, delta S) (*TypedMember[ID, S], error) {
+	memberID, err := l.codec.EncodeID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkExactScore(delta); err != nil {
+		return nil, err
+	}
+
+	var member *TypedMember[ID, S]
+	err = l.transaction(ctx, func(tx *sql.Tx) error {
+		if _, err := l.update(ctx, tx, memberID, delta, UpdateSum); err != nil {
+			return err
+		}
+
+		ranked, args := l.ranked()
+		var score float64
+		member = &TypedMember[ID, S]{ID: id}
+		err := tx.QueryRowContext(ctx, l.dialect.rebind(`SELECT score, member_rank FROM (`+ranked+`) AS ranked WHERE id = ?`), append(args, memberID)...).Scan(&score, &member.Rank)
+		member.Score = S(score)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
+
+// RemoveMember remove members and their metadata from leaderboard by their ids, ids which are not in leaderboard will be ignored.
+func (l *TypedSQLLeaderboard[ID, S]) RemoveMember(ctx context.Context, ids ...ID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	args := make([]interface{}, 0, len(ids)+1)
+	args = append(args, l.name)
+	for _, id := range ids {
+		memberID, err := l.codec.EncodeID(id)
+		if err != nil {
+			return err
+		}
+		args = append(args, memberID)
+	}
+
+	_, err := l.db.ExecContext(ctx, l.dialect.rebind(`DELETE FROM goleaderboard_members WHERE board = ? AND id IN (`+inClause(len(ids))+`)`), args...)
+	return err
+}
+
+// listOffset get limit members from offset in order among members which match condition.
+func (l *TypedSQLLeaderboard[ID, S]) listOffset(ctx context.Context, condition string, conditionArgs []interface{}, offset, limit int, order Order) ([]*TypedMember[ID, S], Cursor, error) {
+	members, args := l.members()
+	total, err := l.queryInt(ctx, `SELECT COUNT(*) `+members+` AND `+condition, append(args, conditionArgs...)...)
+	if err != nil {
+		return nil, Cursor{}, err
+	}
+
+	listMember := []*TypedMember[ID, S]{}
+	if limit > 0 && offset < total {
+		direction := "ASC"
+		if order == OrderAsc {
+			direction = "DESC"
+		}
+
+		ranked, args := l.ranked()
+		args = append(args, conditionArgs...)
+		args = append(args, limit, maxInt(offset, 0))
+		listMember, err = l.queryMembers(ctx, l.db, `SELECT id, score, metadata, member_rank FROM (`+ranked+`) AS ranked
+WHERE `+condition+`
+ORDER BY member_position `+direction+`
+LIMIT ? OFFSET ?`, args...)
+		if err != nil {
+			return nil, Cursor{}, err
+		}
+	}
+
+	return listMember, Cursor{
+		Begin: offset,
+		End:   offset + len(listMember),
+		Total: total,
+	}, nil
+}
+
+// List get list member with offset, limit and order in leaderboard
+func (l *TypedSQLLeaderboard[ID, S]) List(ctx context.Context, offset, limit int, order Order) ([]*TypedMember[ID, S], Cursor, error) {
+	return l.listOffset(ctx, "1 = 1", nil, offset, limit, order)
+}
+
+// ListByScore get list member whose score is in range [min, max] with offset, limit and order in leaderboard.
+// Offset and cursor are counted from the first member in the range.
+func (l *TypedSQLLeaderboard[ID, S]) ListByScore(ctx context.Context, min, max ScoreBound, offset, limit int, order Order) ([]*TypedMember[ID, S], Cursor, error) {
+	condition, args, err := scoreCondition(min, max)
+	if err != nil {
+		return nil, Cursor{}, err
+	}
+
+	return l.listOffset(ctx, condition, args, offset, limit, order)
+}
+
+// GetAround get list member around another member with limit and order.
+// It returns ErrMemberNotFound if member is not in leaderboard.
+func (l *TypedSQLLeaderboard[ID, S]) GetAround(ctx context.Context, id ID, limit int, order Order) ([]*TypedMember[ID, S], Cursor, error) {
+	memberID, err := l.codec.EncodeID(id)
+	if err != nil {
+		return nil, Cursor{}, err
+	}
+
+	ranked, args := l.ranked()
+	position, err := l.queryInt(ctx, `SELECT member_position FROM (`+ranked+`) AS ranked WHERE id = ?`, append(args, memberID)...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, Cursor{}, ErrMemberNotFound
+	}
+	if err != nil {
+		return nil, Cursor{}, err
+	}
+
+	total, err := l.Count(ctx)
+	if err != nil {
+		return nil, Cursor{}, err
+	}
+
+	rank := position - 1
+	if order == OrderAsc {
+		rank = total - position
+	}
+
+	return l.listOffset(ctx, "1 = 1", nil, cursorAround(rank, limit, total), limit, order)
+}
+
+// GetRank get rank of a member.
+// It returns ErrMemberNotFound if member is not in leaderboard.
+func (l *TypedSQLLeaderboard[ID, S]) GetRank(ctx context.Context, id ID) (int, error) {
+	member, err := l.GetMember(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+
+	return member.Rank, nil
+}
+
+// GetPercentile get percentile of a member in leaderboard, it is in range (0, 100] and smaller is better.
+// With RankDense it is the rank of member over number of distinct scores, otherwise it is the rank over number of members.
+// It returns ErrMemberNotFound if member is not in leaderboard.
+func (l *TypedSQLLeaderboard[ID, S]) GetPercentile(ctx context.Context, id ID) (float64, error) {
+	member, err := l.GetMember(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+
+	count := "COUNT(*)"
+	if l.opts.rankingScheme() == RankDense {
+		count = "COUNT(DISTINCT score)"
+	}
+
+	members, args := l.members()
+	total, err := l.queryInt(ctx, `SELECT `+count+` `+members, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return percentile(member.Rank, total), nil
+}
+
+// GetMember get score and rank of a member in one call.
+// It returns ErrMemberNotFound if member is not in leaderboard.
+func (l *TypedSQLLeaderboard[ID, S]) GetMember(ctx context.Context, id ID) (*TypedMember[ID, S], error) {
+	members, err := l.GetMembers(ctx, []ID{id})
+	if err != nil {
+		return nil, err
+	}
+
+	if members[0] == nil {
+		return nil, ErrMemberNotFound
+	}
+
+	return members[0], nil
+}
+
+// rankedIn get members of ids which are in leaderboard with their rank, ordered by their position from the highest one.
+func (l *TypedSQLLeaderboard[ID, S]) rankedIn(ctx context.Context, ids []ID) ([]*TypedMember[ID, S], error) {
+	ranked, args := l.ranked()
+	for _, id := range ids {
+		memberID, err := l.codec.EncodeID(id)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, memberID)
+	}
+
+	return l.queryMembers(ctx, l.db, `SELECT id, score, metadata, member_rank FROM (`+ranked+`) AS ranked
+WHERE id IN (`+inClause(len(ids))+`)
+ORDER BY member_position`, args...)
+}
+
+// GetMembers get score and rank of a list of members in one call.
+// Members are returned in the same order as ids, a member is nil if its id is not in leaderboard.
+func (l *TypedSQLLeaderboard[ID, S]) GetMembers(ctx context.Context, ids []ID) ([]*TypedMember[ID, S], error) {
+	listMember := make([]*TypedMember[ID, S], len(ids))
+	if len(ids) == 0 {
+		return listMember, nil
+	}
+
+	found, err := l.rankedIn(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	// ids listed from database are decoded, so members are matched with ids by their encoded ids
+	foundByID := make(map[string]*TypedMember[ID, S], len(found))
+	for _, member := range found {
+		memberID, err := l.codec.EncodeID(member.ID)
+		if err != nil {
+			return nil, err
+		}
+		foundByID[memberID] = member
+	}
+
+	for idx, id := range ids {
+		memberID, err := l.codec.EncodeID(id)
+		if err != nil {
+			return nil, err
+		}
+
+		if member, ok := foundByID[memberID]; ok {
+			listMember[idx] = &TypedMember[ID, S]{
+				ID:       id,
+				Score:    member.Score,
+				Rank:     member.Rank,
+				Metadata: member.Metadata,
+			}
+		}
+	}
+
+	return listMember, nil
+}
+
+// ListSubset get members of ids with order, for example to rank a member among its friends.
+// Members are ranked among ids like Options.RankingScheme ranks them in leaderboard, Member.SubsetRank is
+// their rank among ids and Member.Rank is their rank in leaderboard. Ids which are not in leaderboard are ignored.
+func (l *TypedSQLLeaderboard[ID, S]) ListSubset(ctx context.Context, ids []ID, order Order) ([]*TypedMember[ID, S], error) {
+	if len(ids) == 0 {
+		return []*TypedMember[ID, S]{}, nil
+	}
+
+	listMember, err := l.rankedIn(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	rankSubset(listMember, l.opts.rankingScheme())
+	if order == OrderAsc {
+		for left, right := 0, len(listMember)-1; left < right; left, right = left+1, right-1 {
+			listMember[left], listMember[right] = listMember[right], listMember[left]
+		}
+	}
+
+	return listMember, nil
+}
+
+// GetRankInSubset get rank of a member among itself and members of ids like ListSubset.
+// It returns ErrMemberNotFound if member is not in leaderboard.
+func (l *TypedSQLLeaderboard[ID, S]) GetRankInSubset(ctx context.Context, id ID, ids []ID) (int, error) {
+	memberID, err := l.codec.EncodeID(id)
+	if err != nil {
+		return 0, err
+	}
+
+	subset := make([]ID, 0, len(ids)+1)
+	subset = append(subset, id)
+	subset = append(subset, ids...)
+
+	listMember, err := l.ListSubset(ctx, subset, OrderDesc)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, member := range listMember {
+		if encoded, _ := l.codec.EncodeID(member.ID); encoded == memberID {
+			return member.SubsetRank, nil
+		}
+	}
+
+	return 0, ErrMemberNotFound
+}
+
+// Count get number of members in leaderboard
+func (l *TypedSQLLeaderboard[ID, S]) Count(ctx context.Context) (int, error) {
+	members, args := l.members()
+	return l.queryInt(ctx, `SELECT COUNT(*) `+members, args...)
+}
+
+// CountByScore get number of members whose score is in range [min, max]
+func (l *TypedSQLLeaderboard[ID, S]) CountByScore(ctx context.Context, min, max ScoreBound) (int, error) {
+	condition, conditionArgs, err := scoreCondition(min, max)
+	if err != nil {
+		return 0, err
+	}
+
+	members, args := l.members()
+	return l.queryInt(ctx, `SELECT COUNT(*) `+members+` AND `+condition, append(args, conditionArgs...)...)
+}
+
+// Clean clear all data of leaderboard in database
+func (l *TypedSQLLeaderboard[ID, S]) Clean(ctx context.Context) error {
+	tx, err := l.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, query := range []string{
+		`DELETE FROM goleaderboard_members WHERE board = ?`,
+		`DELETE FROM goleaderboard_boards WHERE board = ?`,
+	} {
+		if _, err := tx.ExecContext(ctx, l.dialect.rebind(query), l.name); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,120 @@
+package goleaderboard
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+func openSQLite(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "leaderboard.db"))
+	if err != nil {
+		t.Fatal("failed to open sqlite database", err.Error())
+	}
+
+	if err := MigrateSQL(context.Background(), db, SQLiteDialect); err != nil {
+		t.Fatal("failed to migrate sqlite database", err.Error())
+	}
+
+	return db
+}
+
+func TestMigrateSQL(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+	defer db.Close()
+
+	// tables are already created, so it must not create them again
+	if err := MigrateSQL(ctx, db, SQLiteDialect); err != nil {
+		t.Fatal("failed to migrate sqlite database again", err.Error())
+	}
+
+	version := 0
+	if err := db.QueryRowContext(ctx, `SELECT MAX(version) FROM goleaderboard_schema_migrations`).Scan(&version); err != nil {
+		t.Fatal("failed to get schema version", err.Error())
+	}
+
+	if version != len(SQLMigrations()) {
+		t.Errorf("Error in schema version\nExpected: %v\nReceived: %v", len(SQLMigrations()), version)
+	}
+}
+
+func TestSQLDialectRebind(t *testing.T) {
+	query := PostgresDialect.rebind(`SELECT id FROM goleaderboard_members WHERE board = ? AND id IN (?, ?)`)
+	if query != `SELECT id FROM goleaderboard_members WHERE board = $1 AND id IN ($2, $3)` {
+		t.Errorf("Error in rebind query\nReceived: %v", query)
+	}
+}
+
+func TestSQLDialectOrderID(t *testing.T) {
+	leaderboard := &TypedSQLLeaderboard[string, int]{dialect: PostgresDialect, opts: &Options{}}
+	if query, _ := leaderboard.ranked(); !strings.Contains(query, `tie_break DESC, id COLLATE "C" DESC`) {
+		t.Errorf("Error in order of ids in postgres\nExpected: ids ordered by collation C\nReceived: %v", query)
+	}
+
+	leaderboard.dialect = SQLiteDialect
+	if query, _ := leaderboard.ranked(); !strings.Contains(query, `tie_break DESC, id DESC`) {
+		t.Errorf("Error in order of ids in sqlite\nExpected: ids ordered by the binary collation\nReceived: %v", query)
+	}
+}
+
+func TestSQLLeaderboardTieBreak(t *testing.T) {
+	defer func() {
+		now = time.Now
+	}()
+
+	ctx := context.Background()
+	db := openSQLite(t)
+	defer db.Close()
+
+	leaderboard := NewSQLLeaderBoard(db, SQLiteDialect, "test", &Options{TieBreak: TieBreakEarliest})
+	clock := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	for idx, id := range []string{"P3", "P1", "P2"} {
+		setClock(t, clock.Add(time.Duration(idx)*time.Second))
+		addMember(t, ctx, leaderboard, id, 10)
+	}
+
+	getRank(t, ctx, leaderboard, "P3", 1)
+	getRank(t, ctx, leaderboard, "P1", 2)
+	getRank(t, ctx, leaderboard, "P2", 3)
+}
+
+func TestSQLLeaderboardLifeTime(t *testing.T) {
+	defer func() {
+		now = time.Now
+	}()
+
+	ctx := context.Background()
+	db := openSQLite(t)
+	defer db.Close()
+
+	clock := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	setClock(t, clock)
+
+	leaderboard := NewSQLLeaderBoard(db, SQLiteDialect, "test", &Options{LifeTime: time.Hour, IncludeMetadata: true})
+	if err := leaderboard.AddMemberWithMetadata(ctx, "P1", 10, map[string]string{"name": "Duy"}); err != nil {
+		t.Fatal("failed to add member", err.Error())
+	}
+
+	member, err := leaderboard.GetMember(ctx, "P1")
+	if err != nil || member.Metadata["name"] != "Duy" {
+		t.Errorf("Error in get member with metadata\nExpected: metadata of P1\nReceived: %+v, %v", member, err)
+	}
+
+	setClock(t, clock.Add(time.Hour))
+	if total, err := leaderboard.Count(ctx); err != nil || total != 0 {
+		t.Errorf("Error in count expired leaderboard\nExpected: 0\nReceived: %v, %v", total, err)
+	}
+
+	addMember(t, ctx, leaderboard, "P2", 5)
+	getRank(t, ctx, leaderboard, "P2", 1)
+	if _, err := leaderboard.GetRank(ctx, "P1"); !errors.Is(err, ErrMemberNotFound) {
+		t.Errorf("Error in get rank of expired member\nExpected: %v\nReceived: %v", ErrMemberNotFound, err)
+	}
+}
@@ -0,0 +1,205 @@
+package goleaderboard
+
+import (
+	"context"
+)
+
+// listSubset get members of ids which are in leaderboard with their score, rank and rank among them in one script,
+// it also returns encoded ids of listed members to compare ids which are not decoded to the same value, like AnyCodec.
+func (l *TypedRedisLeaderboard[ID, S]) listSubset(ctx context.Context, ids []ID, order Order) ([]*TypedMember[ID, S], []string, error) {
+	memberIDs, err := l.encodeIDs(ids)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	args := make([]interface{}, 0, len(memberIDs)+2)
+	args = append(args, string(l.opts.rankingScheme()), string(order))
+	args = append(args, memberIDs...)
+	listMemberRankTmp, err := l.listSubsetScript.Run(ctx, l.redisClient, l.keys(), args...).Result()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	listMemberRank := listMemberRankTmp.([]interface{})
+	listMember := make([]*TypedMember[ID, S], len(listMemberRank)/4)
+	listMemberID := make([]string, len(listMember))
+	for idx := range listMember {
+		listMemberID[idx] = listMemberRank[idx*4].(string)
+		id, err := l.codec.DecodeID(listMemberID[idx])
+		if err != nil {
+			return nil, nil, err
+		}
+
+		member, err := l.parseScoreRank(id, listMemberRank[idx*4+1:idx*4+3])
+		if err != nil {
+			return nil, nil, err
+		}
+
+		member.SubsetRank, err = parseRank(listMemberRank[idx*4+3])
+		if err != nil {
+			return nil, nil, err
+		}
+		listMember[idx] = member
+	}
+
+	return listMember, listMemberID, nil
+}
+
+// ListSubset get members of ids with order, for example to rank a member among its friends.
+// Members are ranked among ids like Options.RankingScheme ranks them in leaderboard, Member.SubsetRank is
+// their rank among ids and Member.Rank is their rank in leaderboard. Ids which are not in leaderboard are ignored.
+func (l *TypedRedisLeaderboard[ID, S]) ListSubset(ctx context.Context, ids []ID, order Order) ([]*TypedMember[ID, S], error) {
+	if len(ids) == 0 {
+		return []*TypedMember[ID, S]{}, nil
+	}
+
+	listMember, _, err := l.listSubset(ctx, ids, order)
+	if err != nil {
+		return nil, err
+	}
+
+	return listMember, l.attachMetadata(ctx, listMember...)
+}
+
+// GetRankInSubset get rank of a member among itself and members of ids like ListSubset.
+// It returns ErrMemberNotFound if member is not in leaderboard.
+func (l *TypedRedisLeaderboard[ID, S]) GetRankInSubset(ctx context.Context, id ID, ids []ID) (int, error) {
+	memberID, err := l.codec.EncodeID(id)
+	if err != nil {
+		return 0, err
+	}
+
+	subset := make([]ID, 0, len(ids)+1)
+	subset = append(subset, id)
+	subset = append(subset, ids...)
+
+	listMember, listMemberID, err := l.listSubset(ctx, subset, OrderDesc)
+	if err != nil {
+		return 0, err
+	}
+
+	for idx, member := range listMember {
+		if listMemberID[idx] == memberID {
+			return member.SubsetRank, nil
+		}
+	}
+
+	return 0, ErrMemberNotFound
+}
+
+// rankSubset set SubsetRank of members which are ordered from the highest rank in leaderboard like the script of
+// ListSubset does, members with the same score have the same rank unless scheme is RankOrdinal.
+func rankSubset[ID comparable, S Score](listMember []*TypedMember[ID, S], scheme RankingScheme) {
+	distinct := 0
+	for idx, member := range listMember {
+		tie := idx > 0 && listMember[idx-1].Score == member.Score
+		if !tie {
+			distinct++
+		}
+
+		switch scheme {
+		case RankDense:
+			member.SubsetRank = distinct
+		case RankStandardCompetition:
+			member.SubsetRank = idx + 1
+			if tie {
+				member.SubsetRank = listMember[idx-1].SubsetRank
+			}
+		default:
+			member.SubsetRank = idx + 1
+		}
+	}
+
+	if scheme == RankModifiedCompetition {
+		for idx := len(listMember) - 2; idx >= 0; idx-- {
+			if listMember[idx].Score == listMember[idx+1].Score {
+				listMember[idx].SubsetRank = listMember[idx+1].SubsetRank
+			}
+		}
+	}
+}
+
+func initListSubsetScript() string {
+	return initGetRankFunctionScript() + `
+local scheme = ARGV[1]
+local order = ARGV[2]
+
+local rank_set = KEYS[1]
+local member_score_set = KEYS[2]
+
+local member_set = member_score_set
+if scheme == "ordinal" then
+	member_set = rank_set
+end
+
+local members = {}
+local found = {}
+for idx = 3, #ARGV do
+	local id = ARGV[idx]
+	local score = redis.call("ZSCORE", member_set, id)
+	if score and not found[id] then
+		found[id] = true
+
+		local rank = 0
+		if scheme == "ordinal" then
+			rank = redis.call("ZREVRANK", rank_set, id) + 1
+		else
+			rank = get_rank(scheme, member_score_set, rank_set, score)
+		end
+		table.insert(members, {id = id, score = score, rank = rank})
+	end
+end
+
+-- members with the same rank are ordered by id like they are listed from leaderboard
+table.sort(members, function(a, b)
+	if a.rank ~= b.rank then
+		return a.rank < b.rank
+	end
+	return a.id > b.id
+end)
+
+-- members are ranked among them like they are ranked in leaderboard, they have the same score if they have the same rank
+local distinct = 0
+local first = 1
+for idx, member in ipairs(members) do
+	if idx == 1 or members[idx - 1].rank ~= member.rank then
+		distinct = distinct + 1
+		first = idx
+	end
+
+	if scheme == "dense" then
+		member.subset_rank = distinct
+	elseif scheme == "standard_competition" then
+		member.subset_rank = first
+	else
+		member.subset_rank = idx
+	end
+end
+
+if scheme == "modified_competition" then
+	local last = #members
+	for idx = #members, 1, -1 do
+		if idx == #members or members[idx + 1].rank ~= members[idx].rank then
+			last = idx
+		end
+		members[idx].subset_rank = last
+	end
+end
+
+local list_member_with_rank = {}
+local from, to, step = 1, #members, 1
+if order == "asc" then
+	from, to, step = #members, 1, -1
+end
+
+for idx = from, to, step do
+	local member = members[idx]
+	table.insert(list_member_with_rank, member.id)
+	table.insert(list_member_with_rank, member.score)
+	table.insert(list_member_with_rank, tostring(member.rank))
+	table.insert(list_member_with_rank, tostring(member.subset_rank))
+end
+
+return list_member_with_rank
+`
+}
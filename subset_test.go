@@ -0,0 +1,125 @@
+package goleaderboard
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestListSubset(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	testCases := []struct {
+		scheme      RankingScheme
+		ids         []interface{}
+		subsetRanks []int
+		ranks       []int
+		rankInSub   int
+	}{
+		{
+			scheme:      RankOrdinal,
+			ids:         []interface{}{"P3", "P2", "P5"},
+			subsetRanks: []int{1, 2, 3},
+			ranks:       []int{2, 3, 5},
+			rankInSub:   3,
+		},
+		{
+			scheme:      RankDense,
+			ids:         []interface{}{"P3", "P2", "P5"},
+			subsetRanks: []int{1, 1, 2},
+			ranks:       []int{2, 2, 4},
+			rankInSub:   2,
+		},
+		{
+			scheme:      RankStandardCompetition,
+			ids:         []interface{}{"P3", "P2", "P5"},
+			subsetRanks: []int{1, 1, 3},
+			ranks:       []int{2, 2, 5},
+			rankInSub:   3,
+		},
+		{
+			scheme:      RankModifiedCompetition,
+			ids:         []interface{}{"P3", "P2", "P5"},
+			subsetRanks: []int{2, 2, 3},
+			ranks:       []int{3, 3, 5},
+			rankInSub:   3,
+		},
+	}
+
+	for _, tc := range testCases {
+		ctx := context.Background()
+		leaderboard := NewLeaderBoard(redisClient, "test:"+string(tc.scheme), &Options{RankingScheme: tc.scheme})
+		defer clean(t, ctx, leaderboard)
+
+		err := leaderboard.AddMembers(ctx, []Member{
+			{ID: "P1", Score: 50},
+			{ID: "P2", Score: 40},
+			{ID: "P3", Score: 40},
+			{ID: "P4", Score: 30},
+			{ID: "P5", Score: 20},
+			{ID: "P6", Score: 10},
+		})
+		if err != nil {
+			t.Error("failed to add members", err.Error())
+			return
+		}
+
+		members, err := leaderboard.ListSubset(ctx, []interface{}{"P5", "P2", "P9", "P3", "P2"}, OrderDesc)
+		if err != nil {
+			t.Error("failed to list subset", err.Error())
+			return
+		}
+
+		if len(members) != len(tc.ids) {
+			t.Errorf("Error in list subset with %v\nExpected: %v members\nReceived: %+v", tc.scheme, len(tc.ids), members)
+			continue
+		}
+
+		for idx, member := range members {
+			if member.ID != tc.ids[idx] || member.SubsetRank != tc.subsetRanks[idx] || member.Rank != tc.ranks[idx] {
+				t.Errorf("Error in list subset with %v\nExpected: %v at rank #%v of subset and #%v\nReceived: %+v",
+					tc.scheme, tc.ids[idx], tc.subsetRanks[idx], tc.ranks[idx], member)
+			}
+		}
+
+		members, err = leaderboard.ListSubset(ctx, []interface{}{"P5", "P2", "P3"}, OrderAsc)
+		if err != nil {
+			t.Error("failed to list subset", err.Error())
+			return
+		}
+
+		if members[0].ID != "P5" || members[0].SubsetRank != tc.subsetRanks[2] {
+			t.Errorf("Error in list subset asc with %v\nExpected: P5 at rank #%v of subset\nReceived: %+v", tc.scheme, tc.subsetRanks[2], members[0])
+		}
+
+		rank, err := leaderboard.GetRankInSubset(ctx, "P4", []interface{}{"P2", "P3", "P6"})
+		if err != nil || rank != tc.rankInSub {
+			t.Errorf("Error in get rank in subset with %v\nExpected: rank #%v\nReceived: rank #%v, %v", tc.scheme, tc.rankInSub, rank, err)
+		}
+
+		_, err = leaderboard.GetRankInSubset(ctx, "P9", []interface{}{"P2"})
+		if !errors.Is(err, ErrMemberNotFound) {
+			t.Errorf("Error in get rank in subset of unknown member with %v\nExpected: %v\nReceived: %v", tc.scheme, ErrMemberNotFound, err)
+		}
+	}
+}
+
+func TestGetRankInSubsetIntID(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	ctx := context.Background()
+	leaderboard := NewLeaderBoard(redisClient, "test", &Options{})
+	defer clean(t, ctx, leaderboard)
+
+	for id := 40; id < 45; id++ {
+		addMember(t, ctx, leaderboard, id, id)
+	}
+
+	// AnyCodec decodes ids to strings, so 42 is listed as "42"
+	rank, err := leaderboard.GetRankInSubset(ctx, 42, []interface{}{41, 43, 44})
+	if err != nil || rank != 3 {
+		t.Errorf("Error in get rank in subset of int id\nExpected: rank #%v\nReceived: rank #%v, %v", 3, rank, err)
+	}
+}
@@ -0,0 +1,150 @@
+package goleaderboard
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TieBreak is the way to order members which have the same score when they are ranked by RankOrdinal.
+type TieBreak string
+
+var (
+	// TieBreakID orders members with the same score by their id, it is the default.
+	TieBreakID TieBreak = "id"
+	// TieBreakEarliest ranks the member who reached the score first higher.
+	TieBreakEarliest TieBreak = "earliest"
+	// TieBreakLatest ranks the member who reached the score last higher.
+	TieBreakLatest TieBreak = "latest"
+)
+
+// MaxTieBreakScore is the max absolute score of a leaderboard using TieBreakEarliest or TieBreakLatest.
+// The time a member reached its score is stored with the score in a float64 of Redis, so the score has less room.
+const MaxTieBreakScore = 1<<maxTieBreakBits - 1
+
+// maxTieBreakBits is the number of bits of MaxTieBreakScore.
+const maxTieBreakBits = 22
+
+// tieBreakScale is the room kept in a stored score for the time, it is enough for seconds until 2090.
+const tieBreakScale = 1 << 31
+
+var tieBreakEpoch = time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// now is the clock used to break ties.
+var now = time.Now
+
+// useTieBreak reports whether members with the same score are ranked by the time they reached their score.
+// Other ranking schemes than RankOrdinal give members with the same score the same rank, so they don't break ties.
+func (o *Options) useTieBreak() bool {
+	if o.rankingScheme() != RankOrdinal {
+		return false
+	}
+
+	return o.TieBreak == TieBreakEarliest || o.TieBreak == TieBreakLatest
+}
+
+// useTieBreak reports whether stored scores keep the time members reached their score.
+func (l *TypedRedisLeaderboard[ID, S]) useTieBreak() bool {
+	return l.opts.useTieBreak()
+}
+
+// tieBreakElapsed get the time of the current whole second for tie break, a higher one is ranked higher.
+// Every leaderboard breaks ties by whole seconds like Redis does, so members which reached the same score
+// in the same second are ordered by their id.
+func tieBreakElapsed(tieBreak TieBreak) int64 {
+	elapsed := int64(now().Sub(tieBreakEpoch) / time.Second)
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	if elapsed > tieBreakScale-1 {
+		elapsed = tieBreakScale - 1
+	}
+
+	if tieBreak == TieBreakEarliest {
+		return tieBreakScale - 1 - elapsed
+	}
+
+	return elapsed
+}
+
+// tieBreakTime get the part of stored score for the current time.
+func (l *TypedRedisLeaderboard[ID, S]) tieBreakTime() int64 {
+	return tieBreakElapsed(l.opts.TieBreak)
+}
+
+// keyTieBreakTime get the time part of key of a member which reached its score now, a higher one is ranked higher.
+// It is used by leaderboards which keep the time apart from the score, so scores are not limited by tie break.
+func keyTieBreakTime(opts *Options) int64 {
+	if !opts.useTieBreak() {
+		return 0
+	}
+
+	return tieBreakElapsed(opts.TieBreak)
+}
+
+// tieBreakArgs get scale and time of stored scores for scripts.
+func (l *TypedRedisLeaderboard[ID, S]) tieBreakArgs() (int64, int64) {
+	if !l.useTieBreak() {
+		return 1, 0
+	}
+
+	return tieBreakScale, l.tieBreakTime()
+}
+
+// maxScore is the max absolute score which can be stored exactly, 0 means float scores are not limited.
+func (l *TypedRedisLeaderboard[ID, S]) maxScore() int64 {
+	if l.useTieBreak() {
+		return MaxTieBreakScore
+	}
+
+	if isFloatScore[S]() {
+		return 0
+	}
+
+	return maxExactScore
+}
+
+// scoreRange convert bounds of score to bounds of stored score.
+func (l *TypedRedisLeaderboard[ID, S]) scoreRange(min, max ScoreBound) (string, string, error) {
+	if !l.useTieBreak() {
+		return string(min), string(max), nil
+	}
+
+	minRange, err := tieBreakBound(min, true)
+	if err != nil {
+		return "", "", err
+	}
+
+	maxRange, err := tieBreakBound(max, false)
+	if err != nil {
+		return "", "", err
+	}
+
+	return minRange, maxRange, nil
+}
+
+// tieBreakBound convert a bound of score to the bound of stored score, which covers any time of the score.
+func tieBreakBound(bound ScoreBound, isMin bool) (string, error) {
+	if bound == MinScore || bound == MaxScore {
+		return string(bound), nil
+	}
+
+	exclusive := strings.HasPrefix(string(bound), "(")
+	score, err := strconv.ParseFloat(strings.TrimPrefix(string(bound), "("), 64)
+	if err != nil {
+		return "", err
+	}
+
+	// scores are integers with tie break, so a bound with a fraction is rounded to the next score in range
+	switch {
+	case isMin && exclusive:
+		return formatScore(int64(math.Floor(score)+1) * tieBreakScale), nil
+	case isMin:
+		return formatScore(int64(math.Ceil(score)) * tieBreakScale), nil
+	case exclusive:
+		return "(" + formatScore(int64(math.Ceil(score))*tieBreakScale), nil
+	default:
+		return formatScore(int64(math.Floor(score))*tieBreakScale + tieBreakScale - 1), nil
+	}
+}
@@ -0,0 +1,144 @@
+package goleaderboard
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func setClock(t *testing.T, clock time.Time) {
+	t.Helper()
+	now = func() time.Time {
+		return clock
+	}
+}
+
+func TestTieBreak(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+	defer func() {
+		now = time.Now
+	}()
+
+	testCases := []struct {
+		tieBreak TieBreak
+		first    string
+	}{
+		{
+			tieBreak: TieBreakEarliest,
+			first:    "PEarly",
+		},
+		{
+			tieBreak: TieBreakLatest,
+			first:    "PLate",
+		},
+	}
+
+	for _, tc := range testCases {
+		ctx := context.Background()
+		leaderboard := NewLeaderBoard(redisClient, "test", &Options{TieBreak: tc.tieBreak})
+		defer clean(t, ctx, leaderboard)
+
+		clock := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+		setClock(t, clock)
+		// "PEarly" is lower than "PLate" by id, so it would be ranked after it without tie break
+		addMember(t, ctx, leaderboard, "PEarly", 100)
+		addMember(t, ctx, leaderboard, "PLow", 10)
+
+		setClock(t, clock.Add(time.Minute))
+		addMember(t, ctx, leaderboard, "PLate", 100)
+
+		// adding the same score again keeps the time member reached it
+		setClock(t, clock.Add(time.Hour))
+		addMember(t, ctx, leaderboard, "PEarly", 100)
+
+		members, _, err := leaderboard.List(ctx, 0, 3, OrderDesc)
+		if err != nil {
+			t.Error("failed to list members", err.Error())
+			return
+		}
+
+		if members[0].ID != tc.first || members[0].Score != 100 || members[0].Rank != 1 {
+			t.Errorf("Error in tie break %v\nExpected: %v with score 100 at rank #1\nReceived: %+v", tc.tieBreak, tc.first, members[0])
+		}
+
+		if members[2].ID != "PLow" || members[2].Score != 10 {
+			t.Errorf("Error in tie break %v\nExpected: PLow with score 10\nReceived: %+v", tc.tieBreak, members[2])
+		}
+
+		total, err := leaderboard.CountByScore(ctx, Inclusive(100), Inclusive(100))
+		if err != nil {
+			t.Error("failed to count members by score", err.Error())
+			return
+		}
+
+		if total != 2 {
+			t.Errorf("Error in count members by score with tie break\nExpected: %v\nReceived: %v", 2, total)
+		}
+
+		// "PLow" reaches 100 last
+		setClock(t, clock.Add(2*time.Hour))
+		member, err := leaderboard.IncrementScore(ctx, "PLow", 90)
+		if err != nil {
+			t.Error("failed to increment score", err.Error())
+			return
+		}
+
+		expectedRank := 3
+		if tc.tieBreak == TieBreakLatest {
+			expectedRank = 1
+		}
+
+		if member.Score != 100 || member.Rank != expectedRank {
+			t.Errorf("Error in increment score with tie break %v\nExpected: score 100 at rank #%v\nReceived: %+v", tc.tieBreak, expectedRank, member)
+		}
+
+		err = leaderboard.AddMember(ctx, "PHuge", MaxTieBreakScore+1)
+		if !errors.Is(err, ErrScoreOutOfRange) {
+			t.Errorf("Error in add member with huge score\nExpected: %v\nReceived: %v", ErrScoreOutOfRange, err)
+		}
+		clean(t, ctx, leaderboard)
+	}
+}
+
+func TestTieBreakSameSecond(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+	defer func() {
+		now = time.Now
+	}()
+
+	ctx := context.Background()
+	db := openBolt(t, filepath.Join(t.TempDir(), "leaderboard.db"))
+	defer db.Close()
+
+	for _, tieBreak := range []TieBreak{TieBreakEarliest, TieBreakLatest} {
+		opts := &Options{TieBreak: tieBreak}
+		redisBoard := NewLeaderBoard(redisClient, "test", opts)
+		leaderboards := map[string]Leaderboard{
+			"memory": NewMemoryLeaderBoard(opts),
+			"bolt":   NewBoltLeaderBoard(db, "test:"+string(tieBreak), opts),
+		}
+
+		// members reach the same score in the same second, so they are ordered by id on every backend
+		clock := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+		for idx, id := range []string{"PA", "PC", "PB"} {
+			setClock(t, clock.Add(time.Duration(idx)*300*time.Millisecond))
+			addMember(t, ctx, redisBoard, id, 10)
+			for _, leaderboard := range leaderboards {
+				addMember(t, ctx, leaderboard, id, 10)
+			}
+		}
+
+		expected := listAll(t, ctx, redisBoard, OrderDesc)
+		for name, leaderboard := range leaderboards {
+			if received := listAll(t, ctx, leaderboard, OrderDesc); !reflect.DeepEqual(received, expected) {
+				t.Errorf("Error in tie break %v in the same second of %v leaderboard\nExpected: %v\nReceived: %v", tieBreak, name, expected, received)
+			}
+		}
+		clean(t, ctx, redisBoard)
+	}
+}
@@ -0,0 +1,211 @@
+package goleaderboard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// ErrReplaceWindowScore is returned when a rolling window leaderboard is created with UpdateReplace.
+var ErrReplaceWindowScore = errors.New("goleaderboard: scores of rolling window leaderboard can not be replaced")
+
+// WindowOptions contains all configs for rolling window leaderboard
+type WindowOptions struct {
+	// Bucket is the length of time a bucket keeps scores, buckets start at multiples of it in UTC, default is 24 hours.
+	Bucket time.Duration
+	// Size is the number of buckets in the window including the current one, default is 7.
+	Size int
+	// CacheTime is how long merged buckets are cached, default is 10 seconds.
+	CacheTime time.Duration
+	// Clock get the current time to choose the current bucket, default is time.Now.
+	Clock func() time.Time
+	// Options is configs of buckets, buckets are merged like their Options.UpdatePolicy: UpdateSum, the default,
+	// sums scores of buckets by AggregateSum, UpdateKeepHighest and UpdateKeepLowest keep the best score in the window
+	// by AggregateMax and AggregateMin. UpdateReplace can not be used, because a score replaced in one bucket
+	// would still be summed with scores of other buckets.
+	// TieBreak and LifeTime are not used, buckets expire when they leave the window.
+	Options Options
+}
+
+// TypedWindowLeaderboard defines a leaderboard stored in Redis which ranks members by scores of the last buckets,
+// for example top members of the last 7 days. Members are added to the bucket of the current time,
+// buckets in the window are merged on read and cached for WindowOptions.CacheTime.
+type TypedWindowLeaderboard[ID comparable, S Score] struct {
+	mergedReader[ID, S]
+	redisClient redis.UniversalClient
+	name        string
+	codec       IDCodec[ID]
+	mergeScript *redis.Script
+	opts        *WindowOptions
+}
+
+// WindowLeaderboard defines a rolling window leaderboard with member ids of any type.
+type WindowLeaderboard = TypedWindowLeaderboard[interface{}, int]
+
+// NewWindowLeaderBoard create a new rolling window leaderboard stored in Redis with specific name and configs.
+func NewWindowLeaderBoard(redisClient redis.UniversalClient, name string, opts *WindowOptions) (*WindowLeaderboard, error) {
+	return NewTypedWindowLeaderBoard[interface{}, int](redisClient, name, AnyCodec{}, opts)
+}
+
+// NewTypedWindowLeaderBoard create a new rolling window leaderboard stored in Redis whose member ids have type ID
+// and are encoded by codec, scores have type S. It returns an error if a config of Options has an unknown value
+// and ErrReplaceWindowScore if Options.UpdatePolicy is UpdateReplace.
+func NewTypedWindowLeaderBoard[ID comparable, S Score](redisClient redis.UniversalClient, name string, codec IDCodec[ID], opts *WindowOptions) (*TypedWindowLeaderboard[ID, S], error) {
+	if opts == nil {
+		opts = &WindowOptions{}
+	}
+
+	if err := opts.Options.validate(); err != nil {
+		return nil, err
+	}
+	if opts.Options.UpdatePolicy == UpdateReplace {
+		return nil, ErrReplaceWindowScore
+	}
+
+	lb := &TypedWindowLeaderboard[ID, S]{
+		redisClient: redisClient,
+		name:        name,
+		codec:       codec,
+		mergeScript: redis.NewScript(initMergeScript()),
+		opts:        opts,
+	}
+	lb.mergedReader = mergedReader[ID, S]{merged: lb.merged}
+
+	return lb, nil
+}
+
+func (w *TypedWindowLeaderboard[ID, S]) now() time.Time {
+	if w.opts.Clock != nil {
+		return w.opts.Clock()
+	}
+
+	return now()
+}
+
+func (w *TypedWindowLeaderboard[ID, S]) bucket() time.Duration {
+	if w.opts.Bucket > 0 {
+		return w.opts.Bucket
+	}
+
+	return 24 * time.Hour
+}
+
+func (w *TypedWindowLeaderboard[ID, S]) size() int {
+	if w.opts.Size > 0 {
+		return w.opts.Size
+	}
+
+	return 7
+}
+
+func (w *TypedWindowLeaderboard[ID, S]) cacheTime() time.Duration {
+	if w.opts.CacheTime > 0 {
+		return w.opts.CacheTime
+	}
+
+	return 10 * time.Second
+}
+
+// bucketNames get names of buckets in the window, from the current one to the oldest one.
+// With Options.ClusterKeys buckets have the hash tag of the window, so they are merged in the same slot of Redis Cluster.
+func (w *TypedWindowLeaderboard[ID, S]) bucketNames() []string {
+	start := w.now().UTC().Truncate(w.bucket())
+	names := make([]string, 0, w.size())
+	for idx := 0; idx < w.size(); idx++ {
+		bucketStart := start.Add(-time.Duration(idx) * w.bucket())
+		names = append(names, fmt.Sprintf("%s:%s", w.opts.Options.keyName(w.name), bucketStart.Format("2006-01-02T15:04")))
+	}
+
+	return names
+}
+
+func (w *TypedWindowLeaderboard[ID, S]) boardOptions() *Options {
+	opts := w.opts.Options
+	opts.TieBreak = ""
+	opts.LifeTime = 0
+	if opts.UpdatePolicy == "" {
+		opts.UpdatePolicy = UpdateSum
+	}
+	return &opts
+}
+
+// current get the bucket of the current time, it expires when it leaves the window.
+func (w *TypedWindowLeaderboard[ID, S]) current() TypedLeaderboard[ID, S] {
+	opts := w.boardOptions()
+	start := w.now().UTC().Truncate(w.bucket())
+	opts.LifeTime = start.Add(time.Duration(w.size()) * w.bucket()).Sub(w.now())
+	if opts.LifeTime < time.Second {
+		opts.LifeTime = time.Second
+	}
+
+	return NewTypedLeaderBoard[ID, S](w.redisClient, w.bucketNames()[0], w.codec, opts)
+}
+
+// merged get the leaderboard of buckets in the window, buckets are merged again if the cache expired.
+// The merged leaderboard is named by the current bucket, so it is merged again when the window moves.
+func (w *TypedWindowLeaderboard[ID, S]) merged(ctx context.Context) (TypedLeaderboard[ID, S], error) {
+	opts := w.boardOptions()
+	names := w.bucketNames()
+	weights := make([]float64, len(names))
+	for idx := range weights {
+		weights[idx] = 1
+	}
+
+	dest := names[0] + ":window"
+	err := mergeBoards(
+		ctx,
+		w.redisClient,
+		w.mergeScript,
+		dest,
+		false,
+		aggregateOf(opts.updatePolicy()),
+		w.cacheTime(),
+		false,
+		opts,
+		names,
+		weights,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTypedLeaderBoard[ID, S](w.redisClient, dest, w.codec, opts), nil
+}
+
+// AddMember add a member with score to the current bucket, its score is updated by Options.UpdatePolicy.
+func (w *TypedWindowLeaderboard[ID, S]) AddMember(ctx context.Context, id ID, score S) error {
+	return w.current().AddMember(ctx, id, score)
+}
+
+// AddMemberWithMetadata add a member with score and metadata to the current bucket. Reads of the window return
+// metadata of the latest bucket which has the member when Options.IncludeMetadata is set.
+func (w *TypedWindowLeaderboard[ID, S]) AddMemberWithMetadata(ctx context.Context, id ID, score S, metadata map[string]string) error {
+	return w.current().AddMemberWithMetadata(ctx, id, score, metadata)
+}
+
+// AddMembers add a list of members with their score to the current bucket in one call.
+func (w *TypedWindowLeaderboard[ID, S]) AddMembers(ctx context.Context, members []TypedMember[ID, S]) error {
+	return w.current().AddMembers(ctx, members)
+}
+
+// IncrementScore increase score of a member in the current bucket by delta atomically, for example to count events
+// of the window. It returns the member with its score and rank in the current bucket, not in the window.
+func (w *TypedWindowLeaderboard[ID, S]) IncrementScore(ctx context.Context, id ID, delta S) (*TypedMember[ID, S], error) {
+	return w.current().IncrementScore(ctx, id, delta)
+}
+
+// Clean clear all buckets in the window and the merged one in redis
+func (w *TypedWindowLeaderboard[ID, S]) Clean(ctx context.Context) error {
+	names := w.bucketNames()
+	names = append(names, names[0]+":window")
+	for _, name := range names {
+		if err := NewTypedLeaderBoard[ID, S](w.redisClient, name, w.codec, w.boardOptions()).Clean(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
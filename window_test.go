@@ -0,0 +1,192 @@
+package goleaderboard
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWindowLeaderboard(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+	defer func() {
+		now = time.Now
+	}()
+
+	testCases := []struct {
+		allowSameRank bool
+		clusterKeys   bool
+		window        string
+	}{
+		{
+			allowSameRank: false,
+			window:        "test:2026-10-19T00:00:window",
+		},
+		{
+			allowSameRank: true,
+			window:        "test:2026-10-19T00:00:window",
+		},
+		{
+			allowSameRank: false,
+			clusterKeys:   true,
+			window:        "{test}:2026-10-19T00:00:window",
+		},
+	}
+
+	for _, tc := range testCases {
+		ctx := context.Background()
+		clock := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+		setClock(t, clock)
+
+		leaderboard, err := NewWindowLeaderBoard(redisClient, "test", &WindowOptions{
+			Size:    3,
+			Options: Options{AllowSameRank: tc.allowSameRank, UpdatePolicy: UpdateSum, ClusterKeys: tc.clusterKeys},
+		})
+		if err != nil {
+			t.Fatal("failed to create window leaderboard", err.Error())
+		}
+
+		addMember(t, ctx, leaderboard.current(), "P1", 10)
+		addMember(t, ctx, leaderboard.current(), "P1", 10)
+
+		setClock(t, clock.AddDate(0, 0, 1))
+		addMember(t, ctx, leaderboard.current(), "P1", 5)
+		addMember(t, ctx, leaderboard.current(), "P2", 24)
+
+		member, err := leaderboard.GetMember(ctx, "P1")
+		if err != nil {
+			t.Error("failed to get member", err.Error())
+			return
+		}
+
+		if member.Score != 25 || member.Rank != 1 {
+			t.Errorf("Error in get member in window, same rank %v\nExpected: score 25 at rank #1\nReceived: %+v", tc.allowSameRank, member)
+		}
+
+		// the first day leaves the window
+		setClock(t, clock.AddDate(0, 0, 3))
+		members, cursor, err := leaderboard.List(ctx, 0, 10, OrderDesc)
+		if err != nil {
+			t.Error("failed to list members", err.Error())
+			return
+		}
+
+		if cursor.Total != 2 || members[0].ID != "P2" || members[1].ID != "P1" || members[1].Score != 5 || members[1].Rank != 2 {
+			t.Errorf("Error in list member in window, same rank %v\nExpected: P2 then P1 with score 5 at rank #2\nReceived: %+v", tc.allowSameRank, members)
+		}
+
+		members, _, err = leaderboard.GetAround(ctx, "P1", 1, OrderDesc)
+		if err != nil {
+			t.Error("failed to get around member", err.Error())
+			return
+		}
+
+		if len(members) != 1 || members[0].ID != "P1" {
+			t.Errorf("Error in get around member in window, same rank %v\nExpected: P1\nReceived: %+v", tc.allowSameRank, members)
+		}
+
+		ttl, err := redisClient.TTL(ctx, generateRankSetName(tc.window)).Result()
+		if err != nil || ttl <= 0 || ttl > 10*time.Second {
+			t.Errorf("Error in cache of window, same rank %v\nExpected: cached for 10s\nReceived: %v, %v", tc.allowSameRank, ttl, err)
+		}
+
+		for _, day := range []int{0, 1, 3} {
+			setClock(t, clock.AddDate(0, 0, day))
+			if err := leaderboard.Clean(ctx); err != nil {
+				t.Fatal("failed to clean leaderboard", err.Error())
+			}
+		}
+	}
+}
+
+func TestWindowLeaderboardSumByDefault(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+	defer func() {
+		now = time.Now
+	}()
+
+	ctx := context.Background()
+	clock := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	setClock(t, clock)
+
+	leaderboard, err := NewWindowLeaderBoard(redisClient, "test", &WindowOptions{Size: 3})
+	if err != nil {
+		t.Fatal("failed to create window leaderboard", err.Error())
+	}
+	for _, score := range []int{10, 10} {
+		if err := leaderboard.AddMember(ctx, "P1", score); err != nil {
+			t.Fatal("failed to add member", err.Error())
+		}
+	}
+
+	setClock(t, clock.AddDate(0, 0, 1))
+	member, err := leaderboard.IncrementScore(ctx, "P1", 5)
+	if err != nil || member.Score != 5 || member.Rank != 1 {
+		t.Errorf("Error in increment score in the current bucket\nExpected: score 5 at rank #1\nReceived: %+v, %v", member, err)
+	}
+
+	member, err = leaderboard.GetMember(ctx, "P1")
+	if err != nil || member.Score != 25 {
+		t.Errorf("Error in get member in window summed by default\nExpected: score 25\nReceived: %+v, %v", member, err)
+	}
+
+	for _, day := range []int{0, 1} {
+		setClock(t, clock.AddDate(0, 0, day))
+		if err := leaderboard.Clean(ctx); err != nil {
+			t.Fatal("failed to clean leaderboard", err.Error())
+		}
+	}
+
+	_, err = NewWindowLeaderBoard(redisClient, "test", &WindowOptions{Options: Options{UpdatePolicy: UpdateReplace}})
+	if !errors.Is(err, ErrReplaceWindowScore) {
+		t.Errorf("Error in create window leaderboard with %v\nExpected: %v\nReceived: %v", UpdateReplace, ErrReplaceWindowScore, err)
+	}
+}
+
+func TestWindowLeaderboardMetadata(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+	defer func() {
+		now = time.Now
+	}()
+
+	ctx := context.Background()
+	clock := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	setClock(t, clock)
+
+	leaderboard, err := NewWindowLeaderBoard(redisClient, "test", &WindowOptions{
+		Size:    3,
+		Options: Options{IncludeMetadata: true},
+	})
+	if err != nil {
+		t.Fatal("failed to create window leaderboard", err.Error())
+	}
+
+	members := []Member{
+		{ID: "P1", Score: 10, Metadata: map[string]string{"name": "Alice"}},
+		{ID: "P2", Score: 5, Metadata: map[string]string{"name": "Bob"}},
+	}
+	if err := leaderboard.AddMembers(ctx, members); err != nil {
+		t.Fatal("failed to add members", err.Error())
+	}
+
+	// metadata of the current bucket is kept
+	setClock(t, clock.AddDate(0, 0, 1))
+	if err := leaderboard.AddMemberWithMetadata(ctx, "P1", 10, map[string]string{"name": "Alice Nguyen"}); err != nil {
+		t.Fatal("failed to add member with metadata", err.Error())
+	}
+
+	list, _, err := leaderboard.List(ctx, 0, 10, OrderDesc)
+	if err != nil || len(list) != 2 || list[0].Metadata["name"] != "Alice Nguyen" || list[1].Metadata["name"] != "Bob" {
+		t.Errorf("Error in list member with metadata in window\nExpected: Alice Nguyen then Bob\nReceived: %+v, %v", list, err)
+	}
+
+	for _, day := range []int{0, 1} {
+		setClock(t, clock.AddDate(0, 0, day))
+		if err := leaderboard.Clean(ctx); err != nil {
+			t.Fatal("failed to clean leaderboard", err.Error())
+		}
+	}
+}